@@ -0,0 +1,126 @@
+package eoldate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Names of the computed fields Products.Annotate adds to AdditionalFields
+const (
+	FieldStatus           = "status"
+	FieldDaysToEOL        = "days_to_eol"
+	FieldDaysToSupportEnd = "days_to_support_end"
+	FieldEOLIn            = "eol_in"
+)
+
+// Annotation holds the values computed from the dates of a release cycle at a point in time
+type Annotation struct {
+	Status Status `json:"status"`
+	// DaysToEOL and DaysToSupportEnd are negative once the date has passed and nil when the cycle has no such date
+	DaysToEOL        *int `json:"days_to_eol,omitempty"`
+	DaysToSupportEnd *int `json:"days_to_support_end,omitempty"`
+	// EOLIn is the distance to the EOL date in words, such as "in 1y 3m" or "2y 1m ago"
+	EOLIn string `json:"eol_in,omitempty"`
+}
+
+// Annotation computes the status of the cycle and the time left until its EOL and end of active support.
+// Cycles reaching EOL within DefaultWarnDays are reported as approaching EOL
+func (p *Product) Annotation(at time.Time) Annotation {
+	return p.AnnotationWithin(at, DefaultWarnDays)
+}
+
+// AnnotationWithin is like Annotation but reports cycles reaching EOL within warnDays as approaching EOL
+func (p *Product) AnnotationWithin(at time.Time, warnDays int) Annotation {
+	annotation := Annotation{Status: StatusUnknown}
+	switch eol := p.EOL.(type) {
+	case bool:
+		annotation.Status = StatusSupported
+		if eol {
+			annotation.Status = StatusEOL
+		}
+	case string:
+		if eolDate, err := ParseDate(eol); err == nil {
+			days := daysBetween(at, eolDate)
+			annotation.DaysToEOL = &days
+			annotation.Status = EOLDateStatus(eolDate, at, warnDays)
+			annotation.EOLIn = HumanizeDuration(eolDate, at)
+		}
+	}
+	if support, ok := p.Support.(string); ok {
+		if supportDate, err := ParseDate(support); err == nil {
+			days := daysBetween(at, supportDate)
+			annotation.DaysToSupportEnd = &days
+		}
+	}
+	return annotation
+}
+
+// Annotate returns copies of the cycles with their Annotation at the given time added to AdditionalFields
+// under the FieldStatus, FieldDaysToEOL, FieldDaysToSupportEnd and FieldEOLIn keys
+func (p Products) Annotate(at time.Time) Products {
+	return p.AnnotateWithin(at, DefaultWarnDays)
+}
+
+// AnnotateWithin is like Annotate but reports cycles reaching EOL within warnDays as approaching EOL
+func (p Products) AnnotateWithin(at time.Time, warnDays int) Products {
+	annotated := make(Products, len(p))
+	for i, product := range p {
+		fields := make(map[string]interface{}, len(product.AdditionalFields)+4)
+		for key, value := range product.AdditionalFields {
+			fields[key] = value
+		}
+		annotation := product.AnnotationWithin(at, warnDays)
+		fields[FieldStatus] = string(annotation.Status)
+		if annotation.DaysToEOL != nil {
+			fields[FieldDaysToEOL] = *annotation.DaysToEOL
+		}
+		if annotation.DaysToSupportEnd != nil {
+			fields[FieldDaysToSupportEnd] = *annotation.DaysToSupportEnd
+		}
+		if annotation.EOLIn != "" {
+			fields[FieldEOLIn] = annotation.EOLIn
+		}
+		product.AdditionalFields = fields
+		annotated[i] = product
+	}
+	return annotated
+}
+
+// HumanizeDuration describes the distance between at and date in years, months and days,
+// such as "in 1y 3m" for future dates and "2y 1m ago" for past dates. Only the two largest units are shown
+func HumanizeDuration(date, at time.Time) string {
+	years, months, days := CalculateTimeDifferenceAt(date, at)
+	var parts []string
+	for _, unit := range []struct {
+		n      int
+		suffix string
+	}{{years, "y"}, {months, "m"}, {days, "d"}} {
+		if unit.n > 0 && len(parts) < 2 {
+			parts = append(parts, fmt.Sprintf("%d%s", unit.n, unit.suffix))
+		} else if len(parts) > 0 {
+			break
+		}
+	}
+	switch {
+	case len(parts) == 0:
+		return "today"
+	case date.After(at):
+		return "in " + strings.Join(parts, " ")
+	default:
+		return strings.Join(parts, " ") + " ago"
+	}
+}
+
+// EOLDateStatus returns the status at a point in time of a cycle with the given EOL date.
+// It is approaching EOL when the date is at most warnDays days away
+func EOLDateStatus(eolDate, at time.Time, warnDays int) Status {
+	switch {
+	case !at.Before(eolDate):
+		return StatusEOL
+	case daysBetween(at, eolDate) <= warnDays:
+		return StatusApproachingEOL
+	default:
+		return StatusSupported
+	}
+}
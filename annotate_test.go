@@ -0,0 +1,70 @@
+package eoldate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProducts_Annotate(t *testing.T) {
+	at := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	products := Products{
+		{Cycle: "3.12", EOL: "2028-10-31", Support: "2025-04-02"},
+		{Cycle: "3.8", EOL: "2024-07-01"},
+		{Cycle: "3.7", EOL: "2023-06-27", AdditionalFields: map[string]interface{}{"codename": "x"}},
+		{Cycle: "2.7", EOL: true},
+		{Cycle: "bullseye", EOL: false},
+		{Cycle: "unknown"},
+	}
+	tests := []struct {
+		cycle     string
+		status    string
+		daysToEOL interface{}
+		eolIn     interface{}
+	}{
+		{cycle: "3.12", status: "supported", daysToEOL: 1613, eolIn: "in 4y 4m"},
+		{cycle: "3.8", status: "approaching-eol", daysToEOL: 30, eolIn: "in 1m"},
+		{cycle: "3.7", status: "eol", daysToEOL: -340, eolIn: "11m 5d ago"},
+		{cycle: "2.7", status: "eol"},
+		{cycle: "bullseye", status: "supported"},
+		{cycle: "unknown", status: "unknown"},
+	}
+	annotated := products.Annotate(at)
+	for i, tt := range tests {
+		t.Run(tt.cycle, func(t *testing.T) {
+			fields := annotated[i].AdditionalFields
+			if fields[FieldStatus] != tt.status {
+				t.Errorf("Annotate() status = %v, want %v", fields[FieldStatus], tt.status)
+			}
+			if fields[FieldDaysToEOL] != tt.daysToEOL {
+				t.Errorf("Annotate() days_to_eol = %v, want %v", fields[FieldDaysToEOL], tt.daysToEOL)
+			}
+			if fields[FieldEOLIn] != tt.eolIn {
+				t.Errorf("Annotate() eol_in = %v, want %v", fields[FieldEOLIn], tt.eolIn)
+			}
+		})
+	}
+	if got := annotated[0].AdditionalFields[FieldDaysToSupportEnd]; got != 305 {
+		t.Errorf("Annotate() days_to_support_end = %v, want 305", got)
+	}
+	if annotated[2].AdditionalFields["codename"] != "x" || len(products[2].AdditionalFields) != 1 {
+		t.Errorf("Annotate() must keep AdditionalFields without modifying the input")
+	}
+}
+
+func TestEOLDateStatus(t *testing.T) {
+	eolDate := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		at   time.Time
+		want Status
+	}{
+		{at: time.Date(2024, 6, 2, 12, 0, 0, 0, time.UTC), want: StatusSupported},
+		{at: time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC), want: StatusApproachingEOL},
+		{at: time.Date(2024, 8, 31, 23, 0, 0, 0, time.UTC), want: StatusApproachingEOL},
+		{at: eolDate, want: StatusEOL},
+	}
+	for _, tt := range tests {
+		if got := EOLDateStatus(eolDate, tt.at, 90); got != tt.want {
+			t.Errorf("EOLDateStatus(%s, %s, 90) = %s, want %s", eolDate.Format(time.DateOnly), tt.at.Format(time.DateTime), got, tt.want)
+		}
+	}
+}
@@ -7,74 +7,126 @@ import (
 	"time"
 )
 
-// readCache reads the cached data for a product
-func readCache(product string) ([]byte, error) {
+// DefaultCacheDir returns the default directory used to cache API responses, ~/.config/eoldate/cache
+func DefaultCacheDir() (string, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		return nil, LogError(err)
+		return "", LogError(&CacheError{Op: "resolve", Path: "~", Err: err})
+	}
+	return filepath.Join(homeDir, ".config", "eoldate", "cache"), nil
+}
+
+// CacheDir returns the directory used by the client to cache API responses
+func (c *Client) CacheDir() (string, error) {
+	if c.cacheDir != "" {
+		return c.cacheDir, nil
+	}
+	return DefaultCacheDir()
+}
+
+// ClearCache removes all cached API responses from the cache directory
+func (c *Client) ClearCache() error {
+	cacheDir, err := c.CacheDir()
+	if err != nil {
+		return err
+	}
+	cacheFiles, err := filepath.Glob(filepath.Join(cacheDir, "*.json"))
+	if err != nil {
+		return &CacheError{Op: "list", Path: cacheDir, Err: err}
+	}
+	for _, cacheFile := range cacheFiles {
+		if err = os.Remove(cacheFile); err != nil {
+			return &CacheError{Op: "remove", Path: cacheFile, Err: err}
+		}
+	}
+	return nil
+}
+
+// productCacheFile returns the path of today's cache file of a product
+func (c *Client) productCacheFile(product string) (string, error) {
+	cacheDir, err := c.CacheDir()
+	if err != nil {
+		return "", err
 	}
 	timestamp := time.Now().Format("01-02-2006")
-	cacheDir := filepath.Join(homeDir, ".config", "eoldate", "cache")
-	cacheFile := fmt.Sprintf("%s/%s-%s.json", cacheDir, product, timestamp)
+	return fmt.Sprintf("%s/%s-%s.json", cacheDir, product, timestamp), nil
+}
+
+// readCache reads the cached data for a product
+func (c *Client) readCache(product string) ([]byte, error) {
+	cacheFile, err := c.productCacheFile(product)
+	if err != nil {
+		return nil, err
+	}
 	if exists, err := Exists(cacheFile); err == nil && exists {
-		return os.ReadFile(cacheFile)
+		data, err := os.ReadFile(cacheFile)
+		if err != nil {
+			return nil, &CacheError{Op: "read", Path: cacheFile, Err: err}
+		}
+		return data, nil
 	} else {
 		return nil, nil
 	}
 }
 
 // readAllTechnologiesCache ...
-func readAllTechnologiesCache() ([]string, error) {
-	homeDir, err := os.UserHomeDir()
+func (c *Client) readAllTechnologiesCache() ([]string, error) {
+	cacheDir, err := c.CacheDir()
 	if err != nil {
-		return nil, LogError(err)
+		return nil, err
 	}
 	timestamp := time.Now().Format("01-02-2006")
-	cacheDir := filepath.Join(homeDir, ".config", "eoldate", "cache")
 	cacheAllTechFile := fmt.Sprintf("%s/all-technologies-%s.json", cacheDir, timestamp)
 	if exists, err := Exists(cacheAllTechFile); err == nil && exists {
-		return ReadLines(cacheAllTechFile)
+		lines, err := ReadLines(cacheAllTechFile)
+		if err != nil {
+			return nil, &CacheError{Op: "read", Path: cacheAllTechFile, Err: err}
+		}
+		return lines, nil
 	} else {
 		return nil, nil
 	}
 }
 
 // writeCache writes data to the cache for a product
-func writeCache(product string, data []byte) error {
-	homeDir, err := os.UserHomeDir()
+func (c *Client) writeCache(product string, data []byte) error {
+	cacheFile, err := c.productCacheFile(product)
 	if err != nil {
-		return LogError(err)
+		return err
 	}
-	timestamp := time.Now().Format("01-02-2006")
-	cacheDir := filepath.Join(homeDir, ".config", "eoldate", "cache")
-	cacheFile := fmt.Sprintf("%s/%s-%s.json", cacheDir, product, timestamp)
-	return os.WriteFile(cacheFile, data, 0600)
+	if err = os.WriteFile(cacheFile, data, 0600); err != nil {
+		return &CacheError{Op: "write", Path: cacheFile, Err: err}
+	}
+	return nil
 }
 
 // CacheTechnologies caches all available technologies to choose from to a local file cache
 func (c *Client) CacheTechnologies() ([]string, error) {
-	homeDir, err := os.UserHomeDir()
+	cacheDir, err := c.CacheDir()
 	if err != nil {
-		return nil, LogError(err)
+		return nil, err
 	}
 	timestamp := time.Now().Format("01-02-2006")
-	cacheDir := filepath.Join(homeDir, ".config", "eoldate", "cache")
 	allTechnologiesFileCache := fmt.Sprintf("%s/all-technologies-%s.json", cacheDir, timestamp)
 	if exists, err := Exists(cacheDir); err == nil && !exists {
 		if err = os.MkdirAll(cacheDir, 0755); err != nil {
-			return nil, LogError(err)
+			return nil, LogError(&CacheError{Op: "mkdir", Path: cacheDir, Err: err})
 		}
 	}
 
 	if cacheExists, err := Exists(allTechnologiesFileCache); err == nil && cacheExists {
-		return ReadLines(allTechnologiesFileCache)
+		lines, err := ReadLines(allTechnologiesFileCache)
+		if err != nil {
+			return nil, LogError(&CacheError{Op: "read", Path: allTechnologiesFileCache, Err: err})
+		}
+		return lines, nil
 	}
 	allProducts, err := c.GetAllProducts()
 	if err != nil {
 		return nil, LogError(err)
 	}
 	if err = WriteLines(allProducts, allTechnologiesFileCache); err != nil {
-		return nil, LogError(err)
+		return nil, LogError(&CacheError{Op: "write", Path: allTechnologiesFileCache, Err: err})
 	}
 
 	return allProducts, nil
@@ -0,0 +1,213 @@
+package eoldate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Limits of Slack messages, see https://api.slack.com/reference/block-kit/blocks
+const (
+	slackMaxBlocks      = 50
+	slackMaxSectionText = 3000
+)
+
+// chatTitle is the title of Slack and Teams messages
+const chatTitle = "End of life report"
+
+// eventGroup is a severity section of a chat message
+type eventGroup struct {
+	title string
+	// emoji prefixes the title in Slack, color is the Teams text color
+	emoji  string
+	color  string
+	events []Event
+}
+
+// groupEvents groups events by severity: EOL first, then approaching EOL, then other lifecycle changes.
+// Empty groups are left out
+func groupEvents(events []Event) []eventGroup {
+	groups := []eventGroup{
+		{title: "End of life", emoji: ":red_circle:", color: "Attention"},
+		{title: "Approaching end of life", emoji: ":large_orange_circle:", color: "Warning"},
+		{title: "Lifecycle changes", emoji: ":large_blue_circle:", color: "Accent"},
+	}
+	for _, event := range events {
+		switch event.Kind {
+		case EventEOL:
+			groups[0].events = append(groups[0].events, event)
+		case EventApproachingEOL:
+			groups[1].events = append(groups[1].events, event)
+		default:
+			groups[2].events = append(groups[2].events, event)
+		}
+	}
+	var nonEmpty []eventGroup
+	for _, group := range groups {
+		if len(group.events) > 0 {
+			nonEmpty = append(nonEmpty, group)
+		}
+	}
+	return nonEmpty
+}
+
+// chatSummary counts the events of each group, e.g. "2 end of life, 1 approaching end of life"
+func chatSummary(groups []eventGroup) string {
+	if len(groups) == 0 {
+		return "No end of life findings"
+	}
+	parts := make([]string, len(groups))
+	for i, group := range groups {
+		parts[i] = fmt.Sprintf("%d %s", len(group.events), strings.ToLower(group.title))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// slackText is a text object of a Slack block
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// slackBlock is a Slack Block Kit layout block
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+// slackMessage is the body of a Slack incoming webhook or chat.postMessage request
+type slackMessage struct {
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+// SlackPayload is a PayloadFunc building a Slack Block Kit message for an incoming webhook.
+// Events are grouped by severity with their upgrade recommendation and a link to the release notes
+func SlackPayload(events []Event) ([]byte, error) {
+	groups := groupEvents(events)
+	summary := chatSummary(groups)
+	message := slackMessage{
+		Text: fmt.Sprintf("%s: %s", chatTitle, summary),
+		Blocks: []slackBlock{
+			{Type: "header", Text: &slackText{Type: "plain_text", Text: chatTitle}},
+			{Type: "section", Text: &slackText{Type: "mrkdwn", Text: summary}},
+		},
+	}
+	// leave room for the truncation notice and the footer
+	maxBlocks := slackMaxBlocks - 2
+	omitted := 0
+	for _, group := range groups {
+		if len(message.Blocks)+2 > maxBlocks {
+			omitted += len(group.events)
+			continue
+		}
+		message.Blocks = append(message.Blocks, slackBlock{Type: "divider"})
+		text := fmt.Sprintf("%s *%s (%d)*", group.emoji, group.title, len(group.events))
+		for i, event := range group.events {
+			line := "\n• " + slackEscape(event.Message)
+			if event.Link != "" {
+				line += fmt.Sprintf(" <%s|release notes>", event.Link)
+			}
+			if len(text)+len(line) > slackMaxSectionText {
+				message.Blocks = append(message.Blocks, slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: text}})
+				if len(message.Blocks) >= maxBlocks {
+					omitted += len(group.events) - i
+					text = ""
+					break
+				}
+				text = strings.TrimPrefix(line, "\n")
+				continue
+			}
+			text += line
+		}
+		if text != "" {
+			message.Blocks = append(message.Blocks, slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: text}})
+		}
+	}
+	if omitted > 0 {
+		message.Blocks = append(message.Blocks, slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: fmt.Sprintf("_…and %d more_", omitted)}})
+	}
+	message.Blocks = append(message.Blocks, slackBlock{Type: "context", Elements: []slackText{
+		{Type: "mrkdwn", Text: fmt.Sprintf("eoldate %s · %s", CurrentVersion, time.Now().UTC().Format("2006-01-02 15:04 MST"))},
+	}})
+	return json.Marshal(message)
+}
+
+// slackEscape escapes the control characters of Slack mrkdwn
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// teamsElement is an Adaptive Card body element
+type teamsElement struct {
+	Type     string `json:"type"`
+	Text     string `json:"text"`
+	Size     string `json:"size,omitempty"`
+	Weight   string `json:"weight,omitempty"`
+	Color    string `json:"color,omitempty"`
+	IsSubtle bool   `json:"isSubtle,omitempty"`
+	Wrap     bool   `json:"wrap"`
+}
+
+// teamsCard is an Adaptive Card
+type teamsCard struct {
+	Schema  string            `json:"$schema"`
+	Type    string            `json:"type"`
+	Version string            `json:"version"`
+	Body    []teamsElement    `json:"body"`
+	MSTeams map[string]string `json:"msteams,omitempty"`
+}
+
+// teamsAttachment is a card attached to a Teams message
+type teamsAttachment struct {
+	ContentType string    `json:"contentType"`
+	ContentURL  *string   `json:"contentUrl"`
+	Content     teamsCard `json:"content"`
+}
+
+// teamsMessage is the body of a Teams incoming webhook or workflow request
+type teamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+// TeamsPayload is a PayloadFunc building a Microsoft Teams message with an Adaptive Card for an incoming webhook.
+// Events are grouped by severity with their upgrade recommendation and a link to the release notes
+func TeamsPayload(events []Event) ([]byte, error) {
+	groups := groupEvents(events)
+	body := []teamsElement{
+		{Type: "TextBlock", Text: chatTitle, Size: "Large", Weight: "Bolder", Wrap: true},
+		{Type: "TextBlock", Text: chatSummary(groups), IsSubtle: true, Wrap: true},
+	}
+	for _, group := range groups {
+		lines := make([]string, len(group.events))
+		for i, event := range group.events {
+			lines[i] = "- " + event.Message
+			if event.Link != "" {
+				lines[i] += fmt.Sprintf(" ([release notes](%s))", event.Link)
+			}
+		}
+		body = append(body,
+			teamsElement{Type: "TextBlock", Text: fmt.Sprintf("%s (%d)", group.title, len(group.events)), Weight: "Bolder", Color: group.color, Wrap: true},
+			teamsElement{Type: "TextBlock", Text: strings.Join(lines, "\n"), Wrap: true},
+		)
+	}
+	body = append(body, teamsElement{
+		Type: "TextBlock", Text: fmt.Sprintf("eoldate %s · %s", CurrentVersion, time.Now().UTC().Format("2006-01-02 15:04 MST")), Size: "Small", IsSubtle: true, Wrap: true,
+	})
+	return json.Marshal(teamsMessage{
+		Type: "message",
+		Attachments: []teamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content: teamsCard{
+				Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+				Type:    "AdaptiveCard",
+				Version: "1.4",
+				Body:    body,
+				MSTeams: map[string]string{"width": "Full"},
+			},
+		}},
+	})
+}
@@ -0,0 +1,120 @@
+package eoldate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var chatEvents = []Event{
+	{Kind: EventNewRelease, Product: "go", Cycle: "1.23", Message: "go 1.23 released 1.23.2"},
+	{Kind: EventEOL, Product: "php", Cycle: "7.4", Link: "https://www.php.net/ChangeLog-7.php#7.4.33", Message: "php 7.4.33 reached its end of life on 2022-11-28, upgrade to 8.3.12"},
+	{Kind: EventApproachingEOL, Product: "nodejs", Cycle: "18", Message: "nodejs 18.20.4 reaches its end of life in 30 days on 2025-04-30 <soon> & upgrade"},
+}
+
+func TestSlackPayload(t *testing.T) {
+	many := make([]Event, 1000)
+	for i := range many {
+		many[i] = Event{Kind: EventEOL, Product: "php", Message: fmt.Sprintf("php 5.%d reached its end of life %s", i, strings.Repeat("x", 200))}
+	}
+	tests := []struct {
+		name     string
+		events   []Event
+		wantText string
+		want     []string
+	}{
+		{
+			name:     "grouped by severity",
+			events:   chatEvents,
+			wantText: "End of life report: 1 end of life, 1 approaching end of life, 1 lifecycle changes",
+			want: []string{
+				":red_circle: *End of life (1)*\n• php 7.4.33 reached its end of life on 2022-11-28, upgrade to 8.3.12 <https://www.php.net/ChangeLog-7.php#7.4.33|release notes>",
+				":large_orange_circle: *Approaching end of life (1)*\n• nodejs 18.20.4 reaches its end of life in 30 days on 2025-04-30 &lt;soon&gt; &amp; upgrade",
+				":large_blue_circle: *Lifecycle changes (1)*\n• go 1.23 released 1.23.2",
+			},
+		},
+		{
+			name:     "no events",
+			wantText: "End of life report: No end of life findings",
+		},
+		{
+			name:     "truncated",
+			events:   many,
+			wantText: "End of life report: 1000 end of life",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := SlackPayload(tt.events)
+			if err != nil {
+				t.Fatalf("SlackPayload() error = %v", err)
+			}
+			var message slackMessage
+			if err = json.Unmarshal(body, &message); err != nil {
+				t.Fatalf("SlackPayload() = %s, not JSON: %v", body, err)
+			}
+			if message.Text != tt.wantText {
+				t.Errorf("text = %q, want %q", message.Text, tt.wantText)
+			}
+			if len(message.Blocks) > slackMaxBlocks {
+				t.Errorf("%d blocks, want at most %d", len(message.Blocks), slackMaxBlocks)
+			}
+			var sections []string
+			for _, block := range message.Blocks {
+				if block.Text == nil {
+					continue
+				}
+				if len(block.Text.Text) > slackMaxSectionText {
+					t.Errorf("section of %d characters, want at most %d", len(block.Text.Text), slackMaxSectionText)
+				}
+				sections = append(sections, block.Text.Text)
+			}
+			text := strings.Join(sections, "\n")
+			omitted := 0
+			_, _ = fmt.Sscanf(sections[len(sections)-1], "_…and %d more_", &omitted)
+			if shown := strings.Count(text, "• "); shown+omitted != len(tt.events) {
+				t.Errorf("%d events shown and %d omitted, want %d", shown, omitted, len(tt.events))
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(text, want) {
+					t.Errorf("sections = %q, want %q", sections, want)
+				}
+			}
+		})
+	}
+}
+
+func TestTeamsPayload(t *testing.T) {
+	body, err := TeamsPayload(chatEvents)
+	if err != nil {
+		t.Fatalf("TeamsPayload() error = %v", err)
+	}
+	var message teamsMessage
+	if err = json.Unmarshal(body, &message); err != nil {
+		t.Fatalf("TeamsPayload() = %s, not JSON: %v", body, err)
+	}
+	if message.Type != "message" || len(message.Attachments) != 1 || message.Attachments[0].ContentType != "application/vnd.microsoft.card.adaptive" {
+		t.Fatalf("TeamsPayload() = %s, want a message with an adaptive card", body)
+	}
+	card := message.Attachments[0].Content
+	if card.Type != "AdaptiveCard" || card.Version != "1.4" {
+		t.Errorf("card = %s %s, want AdaptiveCard 1.4", card.Type, card.Version)
+	}
+	want := []teamsElement{
+		{Type: "TextBlock", Text: "End of life (1)", Weight: "Bolder", Color: "Attention", Wrap: true},
+		{Type: "TextBlock", Text: "- php 7.4.33 reached its end of life on 2022-11-28, upgrade to 8.3.12 ([release notes](https://www.php.net/ChangeLog-7.php#7.4.33))", Wrap: true},
+		{Type: "TextBlock", Text: "Approaching end of life (1)", Weight: "Bolder", Color: "Warning", Wrap: true},
+		{Type: "TextBlock", Text: "- nodejs 18.20.4 reaches its end of life in 30 days on 2025-04-30 <soon> & upgrade", Wrap: true},
+		{Type: "TextBlock", Text: "Lifecycle changes (1)", Weight: "Bolder", Color: "Accent", Wrap: true},
+		{Type: "TextBlock", Text: "- go 1.23 released 1.23.2", Wrap: true},
+	}
+	if len(card.Body) != len(want)+3 {
+		t.Fatalf("body = %+v, want title, summary, %d group elements and footer", card.Body, len(want))
+	}
+	for i, element := range want {
+		if got := card.Body[i+2]; got != element {
+			t.Errorf("body[%d] = %+v, want %+v", i+2, got, element)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package eoldate
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// DefaultWarnDays is the default number of days before EOL that a version is reported as approaching EOL
+const DefaultWarnDays = 90
+
+// Status describes the support state of an installed version
+type Status string
+
+const (
+	StatusSupported      Status = "supported"
+	StatusApproachingEOL Status = "approaching-eol"
+	StatusEOL            Status = "eol"
+	StatusUnknown        Status = "unknown"
+)
+
+// InventoryItem is a product and installed version pair to check
+type InventoryItem struct {
+	Product string `json:"product" yaml:"product"`
+	Version string `json:"version" yaml:"version"`
+	// Source and Line locate the item when it was found by a scanner
+	Source string `json:"source,omitempty" yaml:"source,omitempty"`
+	Line   int    `json:"line,omitempty" yaml:"line,omitempty"`
+}
+
+// CheckResult is the outcome of checking an installed version against the product's release cycles
+type CheckResult struct {
+	Product            string `json:"product"`
+	Version            string `json:"version"`
+	Status             Status `json:"status"`
+	Cycle              string `json:"cycle,omitempty"`
+	EOLDate            string `json:"eolDate,omitempty"`
+	DaysUntilEOL       *int   `json:"daysUntilEOL,omitempty"`
+	Latest             string `json:"latest,omitempty"`
+	RecommendedUpgrade string `json:"recommendedUpgrade,omitempty"`
+	Link               string `json:"link,omitempty"`
+	Error              string `json:"error,omitempty"`
+	Source             string `json:"source,omitempty"`
+	Line               int    `json:"line,omitempty"`
+	Err                error  `json:"-"`
+}
+
+// CheckOptions controls how installed versions are evaluated
+type CheckOptions struct {
+	// WarnDays reports versions reaching EOL within this many days as approaching EOL
+	WarnDays int
+	// Concurrency is the maximum number of products fetched in parallel
+	Concurrency int
+	// At is the point in time versions are evaluated at, defaults to now
+	At time.Time
+}
+
+// CheckVersion fetches a product and evaluates an installed version against its release cycles
+func (c *Client) CheckVersion(product, version string, opts CheckOptions) CheckResult {
+	products, err := c.GetProduct(strings.ToLower(product))
+	if err != nil {
+		return newErrorResult(product, version, err)
+	}
+	return products.Check(product, version, opts)
+}
+
+// CheckInventory evaluates many installed versions concurrently.
+// Each distinct product is fetched only once and results are returned in the order of items
+func (c *Client) CheckInventory(items []InventoryItem, opts CheckOptions) []CheckResult {
+	names := make([]string, 0, len(items))
+	seen := make(map[string]bool)
+	for _, item := range items {
+		name := strings.ToLower(item.Product)
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	products, errs := c.GetProducts(names, opts.Concurrency)
+
+	results := make([]CheckResult, len(items))
+	for i, item := range items {
+		name := strings.ToLower(item.Product)
+		if err, ok := errs[name]; ok {
+			results[i] = newErrorResult(item.Product, item.Version, err)
+		} else {
+			results[i] = products[name].Check(item.Product, item.Version, opts)
+		}
+		results[i].Source = item.Source
+		results[i].Line = item.Line
+	}
+	return results
+}
+
+// Check evaluates an installed version of the named product against the release cycles
+func (p Products) Check(product, version string, opts CheckOptions) CheckResult {
+	result := CheckResult{Product: product, Version: version, Status: StatusUnknown}
+	if latest, err := p.GetLatestSupportedVersion(); err == nil {
+		result.Latest = latest.String()
+	}
+
+	cycle, belowLowest, err := p.matchCycle(version)
+	if err != nil {
+		return newErrorResult(product, version, err)
+	}
+	result.Cycle = cycle.Cycle
+	result.Link = cycle.Link
+
+	now := opts.At
+	if now.IsZero() {
+		now = time.Now()
+	}
+	switch eol := cycle.EOL.(type) {
+	case bool:
+		if eol {
+			result.Status = StatusEOL
+		} else {
+			result.Status = StatusSupported
+		}
+	default:
+		eolDate, err := cycle.GetEOLDate()
+		if err != nil {
+			return newErrorResult(product, version, err)
+		}
+		days := daysBetween(now, eolDate)
+		result.EOLDate = eolDate.Format("2006-01-02")
+		result.DaysUntilEOL = &days
+		result.Status = EOLDateStatus(eolDate, now, opts.WarnDays)
+	}
+	if belowLowest {
+		result.Status = StatusEOL
+	}
+
+	result.RecommendedUpgrade = recommendUpgrade(result, cycle)
+	return result
+}
+
+// recommendUpgrade suggests the newest release for versions that are EOL or approaching EOL
+// and the latest patch release of the cycle for supported versions that are behind
+func recommendUpgrade(result CheckResult, cycle *Product) string {
+	if result.Status != StatusSupported {
+		return result.Latest
+	}
+	installed, err := semver.NewVersion(result.Version)
+	if err != nil {
+		return ""
+	}
+	latestPatch, err := semver.NewVersion(cycle.Latest)
+	if err != nil || !latestPatch.GreaterThan(installed) {
+		return ""
+	}
+	return cycle.Latest
+}
+
+// newErrorResult returns a CheckResult for a version that could not be evaluated
+func newErrorResult(product, version string, err error) CheckResult {
+	return CheckResult{
+		Product: product,
+		Version: version,
+		Status:  StatusUnknown,
+		Error:   err.Error(),
+		Err:     err,
+	}
+}
+
+// daysBetween returns the number of whole calendar days from the day of from until to
+func daysBetween(from, to time.Time) int {
+	fromDay := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDay := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDay.Sub(fromDay).Hours() / 24)
+}
@@ -0,0 +1,70 @@
+package eoldate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProducts_Check(t *testing.T) {
+	now := time.Now()
+	products := Products{
+		{Cycle: "3.0", EOL: now.AddDate(2, 0, 0).Format("2006-01-02"), Latest: "3.0.5"},
+		{Cycle: "2.0", EOL: now.AddDate(0, 0, 30).Format("2006-01-02"), Latest: "2.0.9"},
+		{Cycle: "1.0", EOL: now.AddDate(-1, 0, 0).Format("2006-01-02"), Latest: "1.0.7"},
+		{Cycle: "0.9", EOL: true, Latest: "0.9.1"},
+	}
+	tests := []struct {
+		name        string
+		version     string
+		wantStatus  Status
+		wantUpgrade string
+	}{
+		{name: "supported and up to date", version: "3.0.5", wantStatus: StatusSupported, wantUpgrade: ""},
+		{name: "supported but behind", version: "3.0.1", wantStatus: StatusSupported, wantUpgrade: "3.0.5"},
+		{name: "approaching EOL", version: "2.0.1", wantStatus: StatusApproachingEOL, wantUpgrade: "3.0.5"},
+		{name: "EOL by date", version: "1.0", wantStatus: StatusEOL, wantUpgrade: "3.0.5"},
+		{name: "EOL by flag", version: "0.9.1", wantStatus: StatusEOL, wantUpgrade: "3.0.5"},
+		{name: "older than every cycle", version: "0.1", wantStatus: StatusEOL, wantUpgrade: "3.0.5"},
+		{name: "invalid version", version: "latest", wantStatus: StatusUnknown, wantUpgrade: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := products.Check("example", tt.version, CheckOptions{WarnDays: DefaultWarnDays})
+			if got.Status != tt.wantStatus {
+				t.Errorf("Check() status = %v, want %v", got.Status, tt.wantStatus)
+			}
+			if got.RecommendedUpgrade != tt.wantUpgrade {
+				t.Errorf("Check() recommendedUpgrade = %v, want %v", got.RecommendedUpgrade, tt.wantUpgrade)
+			}
+		})
+	}
+}
+
+func TestParseInventory(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		format  string
+		want    int
+		wantErr bool
+	}{
+		{name: "csv with header", data: "product,version\nphp,7.4\nnodejs,20\n", format: InventoryCSV, want: 2},
+		{name: "csv without header", data: "php,7.4\n", format: InventoryCSV, want: 1},
+		{name: "json", data: `[{"product":"php","version":"7.4"}]`, format: InventoryJSON, want: 1},
+		{name: "yaml", data: "- product: php\n  version: 7.4\n- product: nodejs\n  version: 20\n", format: InventoryYAML, want: 2},
+		{name: "missing version", data: "php\n", format: InventoryCSV, wantErr: true},
+		{name: "unknown format", data: "", format: "toml", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseInventory([]byte(tt.data), tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseInventory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.want {
+				t.Errorf("ParseInventory() got %d items, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/projectdiscovery/gologger"
+)
+
+// runCache inspects or clears the local API response cache
+func runCache(g *globalOptions, args []string) int {
+	fs := g.newFlagSet("cache", "eoldate cache [flags] <dir|list|clear>\n\n  dir    print the cache directory\n  list   list cached API responses\n  clear  remove all cached API responses, snapshots in the history directory are kept")
+	if err := g.parseFlags(fs, args); err != nil {
+		return flagExitCode(err, exitUsage)
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	client := g.client()
+	cacheDir, err := client.CacheDir()
+	if err != nil {
+		gologger.Error().Msg(err.Error())
+		return exitError
+	}
+
+	switch fs.Arg(0) {
+	case "dir":
+		fmt.Println(cacheDir)
+	case "list":
+		cacheFiles, err := filepath.Glob(filepath.Join(cacheDir, "*.json"))
+		if err != nil {
+			gologger.Error().Msg(err.Error())
+			return exitError
+		}
+		for _, cacheFile := range cacheFiles {
+			info, err := os.Stat(cacheFile)
+			if err != nil {
+				continue
+			}
+			fmt.Printf("%-60s %8d bytes  %s\n", filepath.Base(cacheFile), info.Size(), info.ModTime().Format("2006-01-02 15:04"))
+		}
+	case "clear":
+		if err = client.ClearCache(); err != nil {
+			gologger.Error().Msg(err.Error())
+			return exitError
+		}
+		gologger.Info().Msgf("Cleared cache %s", cacheDir)
+	default:
+		fs.Usage()
+		return exitUsage
+	}
+	return exitOK
+}
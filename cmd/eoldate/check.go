@@ -0,0 +1,152 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mr-pmillz/eoldate"
+)
+
+// exit codes returned by the check subcommand so pipelines can gate on them
+const (
+	exitSupported      = 0
+	exitApproachingEOL = 1
+	exitEOL            = 2
+	exitLookupError    = 3
+)
+
+// runCheck checks whether installed versions of technologies are still supported
+func runCheck(g *globalOptions, args []string) int {
+	fs := g.newFlagSet("check", "eoldate check -t <technology> -v <version> [flags]\n       eoldate check -input <inventory|-> [flags]")
+	tech := fs.String("t", "", "technology/software name to check")
+	version := fs.String("v", "", "installed version to check")
+	input := fs.String("input", "", "inventory file of product,version pairs (.csv, .json, .yaml) or - for stdin")
+	inputFormat := fs.String("input-format", "", "inventory format: csv, json or yaml (default: detected)")
+	warnDays := fs.Int("warn-days", eoldate.DefaultWarnDays, "report versions reaching EOL within this many days as approaching EOL")
+	concurrency := fs.Int("concurrency", 8, "number of products to fetch in parallel")
+	junitApproaching := fs.String("junit-approaching", junitSkipped, "how -format junit reports versions approaching EOL: skipped, failure or passed")
+	remindDays := fs.Int("remind-days", defaultRemindDays, "days before each event that -format ics sets a reminder, 0 for none")
+	var notify notifyOptions
+	notify.register(fs)
+	usage := fs.Usage
+	fs.Usage = func() {
+		usage()
+		printCheckExitCodes(fs)
+	}
+	if err := g.parseFlags(fs, args); err != nil {
+		return flagExitCode(err, exitLookupError)
+	}
+	notifier, err := notify.notifier()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+		return exitLookupError
+	}
+
+	opts := eoldate.CheckOptions{WarnDays: *warnDays, Concurrency: *concurrency, At: g.asOfTime}
+	client := g.client()
+
+	var results []eoldate.CheckResult
+	switch {
+	case *input != "":
+		items, err := eoldate.ReadInventoryFile(*input, *inputFormat)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: reading inventory %s: %v\n", *input, err)
+			return exitLookupError
+		}
+		results = client.CheckInventory(items, opts)
+		if g.format == "" {
+			g.format = "table"
+		}
+	case *tech != "" && *version != "":
+		results = []eoldate.CheckResult{client.CheckVersion(*tech, *version, opts)}
+	default:
+		fmt.Fprintln(os.Stderr, "ERROR: either -t and -v or -input is required")
+		fs.Usage()
+		return exitLookupError
+	}
+
+	switch {
+	case notify.dryRun:
+		// the notification body replaces the report on stdout
+	case g.tmpl != nil:
+		err = g.executeTemplate(os.Stdout, templateData{WarnDays: *warnDays, Results: results})
+	case g.format == "":
+		printVerdict(results[0], g.now())
+	default:
+		err = writeCheckReport(os.Stdout, g.format, &checkReport{results: results, color: g.color(), now: g.now(), warnDays: *warnDays, junitApproaching: *junitApproaching, remindDays: *remindDays})
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+		return exitLookupError
+	}
+	if err := notifyCheckResults(notifier, results, g); err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+		return exitLookupError
+	}
+	return checkExitCode(results)
+}
+
+// printCheckExitCodes documents the exit codes of the check and scan commands
+func printCheckExitCodes(fs *flag.FlagSet) {
+	fmt.Fprintf(fs.Output(), "\nExit codes (the worst result wins for multiple versions):\n  %d supported\n  %d approaching EOL\n  %d EOL\n  %d lookup error\n",
+		exitSupported, exitApproachingEOL, exitEOL, exitLookupError)
+}
+
+// checkExitCode returns the exit code of the worst result
+func checkExitCode(results []eoldate.CheckResult) int {
+	code := exitSupported
+	for _, result := range results {
+		var c int
+		switch result.Status {
+		case eoldate.StatusSupported:
+			c = exitSupported
+		case eoldate.StatusApproachingEOL:
+			c = exitApproachingEOL
+		case eoldate.StatusEOL:
+			c = exitEOL
+		default:
+			c = exitLookupError
+		}
+		if c > code {
+			code = c
+		}
+	}
+	return code
+}
+
+// printVerdict prints a concise one line verdict for a single check result
+func printVerdict(result eoldate.CheckResult, now time.Time) {
+	latestInfo := ""
+	if result.Latest != "" {
+		latestInfo = fmt.Sprintf(" Latest version is %s.", result.Latest)
+	}
+	if result.RecommendedUpgrade != "" && result.RecommendedUpgrade != result.Latest {
+		latestInfo += fmt.Sprintf(" Upgrade to %s.", result.RecommendedUpgrade)
+	}
+	name := fmt.Sprintf("%s %s (cycle %s)", result.Product, result.Version, result.Cycle)
+	eolDate, err := time.Parse("2006-01-02", result.EOLDate)
+	hasDate := err == nil
+
+	switch {
+	case result.Status == eoldate.StatusEOL && hasDate:
+		fmt.Printf("EOL: %s reached end of life on %s (%s ago).%s\n", name, result.EOLDate, humanizeDuration(eolDate, now), latestInfo)
+	case result.Status == eoldate.StatusEOL:
+		fmt.Printf("EOL: %s has reached end of life.%s\n", name, latestInfo)
+	case result.Status == eoldate.StatusApproachingEOL:
+		fmt.Printf("APPROACHING EOL: %s reaches end of life on %s (in %s).%s\n", name, result.EOLDate, humanizeDuration(eolDate, now), latestInfo)
+	case result.Status == eoldate.StatusSupported && hasDate:
+		fmt.Printf("SUPPORTED: %s is supported until %s (in %s).%s\n", name, result.EOLDate, humanizeDuration(eolDate, now), latestInfo)
+	case result.Status == eoldate.StatusSupported:
+		fmt.Printf("SUPPORTED: %s has no announced end of life.%s\n", name, latestInfo)
+	default:
+		fmt.Fprintf(os.Stderr, "ERROR: %s %s: %s\n", result.Product, result.Version, result.Error)
+	}
+}
+
+// humanizeDuration formats the distance between now and date as years, months and days
+func humanizeDuration(date, now time.Time) string {
+	years, months, days := eoldate.CalculateTimeDifferenceAt(date, now)
+	return fmt.Sprintf("%d years, %d months, and %d days", years, months, days)
+}
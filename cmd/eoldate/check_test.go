@@ -0,0 +1,39 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunCheck_ExitCodes(t *testing.T) {
+	cacheDir := writeCacheFixture(t, map[string]string{"php": phpFixture})
+	inventory := filepath.Join(t.TempDir(), "inventory.csv")
+	if err := os.WriteFile(inventory, []byte("product,version\nphp,8.3.12\nphp,8.1.30\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{name: "supported", args: []string{"-t", "php", "-v", "8.3.12"}, want: exitSupported},
+		{name: "approaching EOL", args: []string{"-t", "php", "-v", "8.1"}, want: exitApproachingEOL},
+		{name: "EOL", args: []string{"-t", "php", "-v", "7.4.33"}, want: exitEOL},
+		{name: "warn days", args: []string{"-t", "php", "-v", "8.1", "-warn-days", "7"}, want: exitSupported},
+		{name: "unknown product", args: []string{"-t", "nope", "-v", "1.0"}, want: exitLookupError},
+		{name: "unknown cycle", args: []string{"-t", "php", "-v", "9.0"}, want: exitLookupError},
+		{name: "worst result of an inventory", args: []string{"-input", inventory, "-format", "json"}, want: exitApproachingEOL},
+		{name: "missing version", args: []string{"-t", "php"}, want: exitLookupError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"check", "-cache-dir", cacheDir, "-as-of", "2025-01-01"}, tt.args...)
+			var got int
+			captureStdout(t, func() { got = run(args) })
+			if got != tt.want {
+				t.Errorf("run(%v) = %d, want %d", args, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mr-pmillz/eoldate"
+	"github.com/projectdiscovery/gologger"
+)
+
+// productDiff is the output of the diff command
+type productDiff struct {
+	Product string `json:"product" yaml:"product"`
+	// Since is the date of the snapshot the current data is compared with
+	Since   string                  `json:"since,omitempty" yaml:"since,omitempty"`
+	Changes []eoldate.ProductChange `json:"changes" yaml:"changes"`
+}
+
+// diffMarkers prefix each kind of change in the text output
+var diffMarkers = map[eoldate.ChangeKind]struct{ marker, color string }{
+	eoldate.ChangeAdded:    {"+", ansiGreen},
+	eoldate.ChangeModified: {"~", ansiYellow},
+	eoldate.ChangeRemoved:  {"-", ansiRed},
+}
+
+// exitChanged is returned by the diff subcommand with -exit-code when the product changed,
+// distinct from exitError and exitUsage so scheduled jobs can tell changes from failures
+const exitChanged = 3
+
+// runDiff compares the current data of a product with a snapshot recorded in the cache
+func runDiff(g *globalOptions, args []string) int {
+	fs := g.newFlagSet("diff", "eoldate diff [flags] <product>")
+	since := fs.String("since", "", "compare with the latest snapshot taken on or before this date (YYYY-MM-DD) instead of the last change")
+	exitCode := fs.Bool("exit-code", false, "exit with 3 when there are changes")
+	usage := fs.Usage
+	fs.Usage = func() {
+		usage()
+		fmt.Fprintf(fs.Output(), "\nA snapshot of a product is kept in the cache history directory whenever its data changed since the last fetch.\nWithout -since the current data is compared with the snapshot before the most recent change.\n")
+		fmt.Fprintf(fs.Output(), "\nExit codes:\n  %d no changes, or changes without -exit-code\n  %d error\n  %d usage error\n  %d changes with -exit-code\n",
+			exitOK, exitError, exitUsage, exitChanged)
+	}
+	if err := g.parseFlags(fs, args); err != nil {
+		return flagExitCode(err, exitUsage)
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+	if _, ok := diffFormats[g.format]; !ok {
+		fmt.Fprintf(fs.Output(), "format %q does not support the diff command\n", g.format)
+		return exitUsage
+	}
+	var sinceDate time.Time
+	if *since != "" {
+		var err error
+		if sinceDate, err = time.Parse("2006-01-02", *since); err != nil {
+			fmt.Fprintf(fs.Output(), "invalid -since date %q, expected YYYY-MM-DD\n", *since)
+			return exitUsage
+		}
+	}
+
+	name := strings.ToLower(fs.Arg(0))
+	client := g.client()
+	current, err := client.GetProduct(name)
+	if err != nil {
+		gologger.Error().Msg(err.Error())
+		return exitError
+	}
+	snapshots, err := client.Snapshots(name)
+	if err != nil {
+		gologger.Error().Msg(err.Error())
+		return exitError
+	}
+
+	diff := productDiff{Product: name}
+	if *since != "" {
+		diff.Since, diff.Changes, err = diffSince(snapshots, current, sinceDate)
+	} else {
+		diff.Since, diff.Changes, err = diffLastChange(snapshots, current)
+	}
+	if err != nil {
+		gologger.Error().Msg(err.Error())
+		return exitError
+	}
+
+	if err = writeDiff(os.Stdout, g.format, diff, g.color()); err != nil {
+		gologger.Error().Msg(err.Error())
+		return exitError
+	}
+	if *exitCode && len(diff.Changes) > 0 {
+		return exitChanged
+	}
+	return exitOK
+}
+
+// diffSince compares current with the latest snapshot taken on or before since,
+// falling back to the oldest snapshot when there is none that old
+func diffSince(snapshots []eoldate.Snapshot, current eoldate.Products, since time.Time) (string, []eoldate.ProductChange, error) {
+	if len(snapshots) == 0 {
+		return "", nil, nil
+	}
+	baseline := snapshots[0]
+	for _, snapshot := range snapshots {
+		if snapshot.Date.After(since) {
+			break
+		}
+		baseline = snapshot
+	}
+	if baseline.Date.After(since) {
+		gologger.Info().Msgf("No snapshot of %s on or before %s, comparing with the oldest from %s",
+			baseline.Product, since.Format("2006-01-02"), baseline.Date.Format("2006-01-02"))
+	}
+	old, err := baseline.Products()
+	if err != nil {
+		return "", nil, err
+	}
+	return baseline.Date.Format("2006-01-02"), eoldate.DiffProducts(old, current), nil
+}
+
+// diffLastChange compares current with the newest snapshot that differs from it
+func diffLastChange(snapshots []eoldate.Snapshot, current eoldate.Products) (string, []eoldate.ProductChange, error) {
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		old, err := snapshots[i].Products()
+		if err != nil {
+			return "", nil, err
+		}
+		if changes := eoldate.DiffProducts(old, current); len(changes) > 0 {
+			return snapshots[i].Date.Format("2006-01-02"), changes, nil
+		}
+	}
+	if len(snapshots) > 0 {
+		return snapshots[0].Date.Format("2006-01-02"), nil, nil
+	}
+	return "", nil, nil
+}
+
+// diffFormats are the formats of the diff command, the empty format is the text output
+var diffFormats = map[string]func(w io.Writer, diff productDiff, color bool) error{
+	"":      writeDiffText,
+	"table": writeDiffText,
+	"json":  func(w io.Writer, diff productDiff, _ bool) error { return writeJSONIndent(w, diff) },
+	"jsonl": func(w io.Writer, diff productDiff, _ bool) error {
+		return writeJSONLines(w, len(diff.Changes), func(i int) interface{} { return diff.Changes[i] })
+	},
+	"yaml": func(w io.Writer, diff productDiff, _ bool) error { return writeYAML(w, diff) },
+	"markdown": func(w io.Writer, diff productDiff, _ bool) error {
+		rows := make([][]string, len(diff.Changes))
+		for i, change := range diff.Changes {
+			rows[i] = []string{change.Cycle, string(change.Kind), change.Field, diffValue(change.Old), diffValue(change.New)}
+		}
+		return writeMarkdownTable(w, []string{"cycle", "change", "field", "old", "new"}, rows)
+	},
+}
+
+// writeDiff writes the changes of a product, one per line unless a format is selected
+func writeDiff(w io.Writer, format string, diff productDiff, color bool) error {
+	render, ok := diffFormats[format]
+	if !ok {
+		return fmt.Errorf("format %q does not support the diff command", format)
+	}
+	return render(w, diff, color)
+}
+
+// writeDiffText writes the changes of a product one per line with a marker of their kind
+func writeDiffText(w io.Writer, diff productDiff, color bool) error {
+	var sb strings.Builder
+	switch {
+	case diff.Since == "":
+		fmt.Fprintf(&sb, "%s: no snapshots recorded yet, they are taken whenever its data is fetched from the API\n", diff.Product)
+	case len(diff.Changes) == 0:
+		fmt.Fprintf(&sb, "%s: no changes since %s\n", diff.Product, diff.Since)
+	default:
+		fmt.Fprintf(&sb, "%s: %d changes since %s\n", diff.Product, len(diff.Changes), diff.Since)
+	}
+	for _, change := range diff.Changes {
+		marker := diffMarkers[change.Kind]
+		if color {
+			fmt.Fprintf(&sb, "  %s%s %s%s\n", marker.color, marker.marker, change, ansiReset)
+		} else {
+			fmt.Fprintf(&sb, "  %s %s\n", marker.marker, change)
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// diffValue formats an old or new value for tables, leaving missing values empty
+func diffValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunDiff_ExitCode(t *testing.T) {
+	// the snapshot predates the release of 8.3
+	old := "[" + strings.SplitN(phpFixture, "\n", 3)[2]
+	tests := []struct {
+		name     string
+		snapshot string
+		args     []string
+		want     int
+	}{
+		{name: "changes", snapshot: old, args: []string{"-exit-code"}, want: exitChanged},
+		{name: "changes without -exit-code", snapshot: old, want: exitOK},
+		{name: "no changes", snapshot: phpFixture, args: []string{"-exit-code"}, want: exitOK},
+		{name: "invalid -since", snapshot: old, args: []string{"-exit-code", "-since", "yesterday"}, want: exitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeCacheFixture(t, map[string]string{"php": phpFixture})
+			history := filepath.Join(dir, "history", "php")
+			if err := os.MkdirAll(history, 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(history, "2023-01-01.json"), []byte(tt.snapshot), 0600); err != nil {
+				t.Fatal(err)
+			}
+			args := append([]string{"diff", "-cache-dir", dir, "-no-color"}, tt.args...)
+			var code int
+			captureStdout(t, func() { code = run(append(args, "php")) })
+			if code != tt.want {
+				t.Errorf("run(%q) = %d, want %d", args, code, tt.want)
+			}
+		})
+	}
+
+	// an unsupported format is a usage error reported before the product is fetched into the cache
+	dir := t.TempDir()
+	args := []string{"diff", "-cache-dir", dir, "-format", "sarif", "php"}
+	var code int
+	captureStdout(t, func() { code = run(args) })
+	if code != exitUsage {
+		t.Errorf("run(%q) = %d, want %d", args, code, exitUsage)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("run(%q) wrote %d cache entries, want the product not fetched", args, len(entries))
+	}
+}
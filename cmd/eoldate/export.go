@@ -0,0 +1,84 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mr-pmillz/eoldate"
+	"github.com/projectdiscovery/gologger"
+	_ "modernc.org/sqlite"
+)
+
+// runExport exports lifecycle data to a database for ad-hoc queries
+func runExport(g *globalOptions, args []string) int {
+	fs := g.newFlagSet("export", "eoldate export [flags] sqlite <db>")
+	products := fs.String("products", "", "comma separated products to export (default: all products)")
+	concurrency := fs.Int("concurrency", 8, "number of products to fetch in parallel")
+	warnDays := fs.Int("warn-days", eoldate.DefaultWarnDays, "store cycles reaching EOL within this many days as approaching EOL")
+	usage := fs.Usage
+	fs.Usage = func() {
+		usage()
+		fmt.Fprintf(fs.Output(), "\nWrites the products, cycles, cycle_fields and exports tables. Running it again refreshes the database,\nonly rewriting products whose data changed and recomputing the status of every cycle.\n")
+	}
+	if err := g.parseFlags(fs, args); err != nil {
+		return flagExitCode(err, exitUsage)
+	}
+	if fs.NArg() != 2 || fs.Arg(0) != "sqlite" {
+		fs.Usage()
+		return exitUsage
+	}
+	dbPath := fs.Arg(1)
+
+	client := g.client()
+	names := splitList(*products)
+	if len(names) == 0 {
+		all, err := client.CacheTechnologies()
+		if err != nil {
+			gologger.Error().Msg(err.Error())
+			return exitError
+		}
+		names = all
+	}
+	gologger.Info().Msgf("Fetching %d products", len(names))
+	data, errs := client.GetProducts(names, *concurrency)
+	failed := make([]string, 0, len(errs))
+	for name, err := range errs {
+		gologger.Error().Msgf("Failed to fetch %s: %v", name, err)
+		failed = append(failed, name)
+	}
+	info, err := client.GetProductsInfo()
+	if err != nil {
+		gologger.Info().Msgf("Product metadata unavailable, exporting without labels and categories: %v", err)
+	}
+
+	if dir := filepath.Dir(dbPath); dir != "." {
+		if err = os.MkdirAll(dir, 0750); err != nil {
+			gologger.Error().Msg(err.Error())
+			return exitError
+		}
+	}
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		gologger.Error().Msg(err.Error())
+		return exitError
+	}
+	defer db.Close()
+	export, err := eoldate.ExportSQLite(db, data, eoldate.SQLiteOptions{
+		At:       g.asOfTime,
+		WarnDays: warnDays,
+		Info:     info,
+		Source:   eoldate.EOLBaseURL,
+		Failed:   failed,
+	})
+	if err != nil {
+		gologger.Error().Msgf("Exporting to %s: %v", dbPath, err)
+		return exitError
+	}
+	gologger.Info().Msgf("Exported %d products to %s, %d updated", export.Products, dbPath, export.Updated)
+	if len(failed) > 0 {
+		return exitError
+	}
+	return exitOK
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/mr-pmillz/eoldate"
+)
+
+// cycleFilters are the flags narrowing down the release cycles of a product
+type cycleFilters struct {
+	supportedOnly bool
+	eolOnly       bool
+	ltsOnly       bool
+	since         string
+	cycle         string
+}
+
+// register adds the filter flags to a flag set
+func (f *cycleFilters) register(fs *flag.FlagSet) {
+	fs.BoolVar(&f.supportedOnly, "supported-only", false, "only show cycles that have not reached their end of life")
+	fs.BoolVar(&f.eolOnly, "eol-only", false, "only show cycles that have reached their end of life")
+	fs.BoolVar(&f.ltsOnly, "lts-only", false, "only show long term support cycles")
+	fs.StringVar(&f.since, "since", "", "only show cycles released since this year or date, e.g. 2020 or 2020-06-01")
+	fs.StringVar(&f.cycle, "cycle", "", "only show cycles matching a version constraint, e.g. '>=3.8'")
+}
+
+// predicates converts the filter flags into library predicates evaluated at the given time
+func (f *cycleFilters) predicates(at time.Time) ([]eoldate.Predicate, error) {
+	if f.supportedOnly && f.eolOnly {
+		return nil, fmt.Errorf("-supported-only and -eol-only are mutually exclusive")
+	}
+	var predicates []eoldate.Predicate
+	if f.supportedOnly {
+		predicates = append(predicates, eoldate.SupportedAt(at))
+	}
+	if f.eolOnly {
+		predicates = append(predicates, eoldate.EOLAt(at))
+	}
+	if f.ltsOnly {
+		predicates = append(predicates, eoldate.LTSAt(at))
+	}
+	if f.since != "" {
+		since, err := eoldate.ParseDate(f.since)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -since: %w", err)
+		}
+		predicates = append(predicates, eoldate.ReleasedSince(since))
+	}
+	if f.cycle != "" {
+		predicate, err := eoldate.CycleConstraint(f.cycle)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -cycle: %w", err)
+		}
+		predicates = append(predicates, predicate)
+	}
+	return predicates, nil
+}
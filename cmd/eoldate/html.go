@@ -0,0 +1,178 @@
+package main
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/mr-pmillz/eoldate"
+)
+
+//go:embed templates/report.html
+var htmlReportTemplate string
+
+// htmlReportTmpl renders the self-contained HTML report
+var htmlReportTmpl = template.Must(template.New("report").Parse(htmlReportTemplate))
+
+// htmlReport is the data of the HTML report
+type htmlReport struct {
+	Title     string
+	Generated string
+	Summary   []htmlSummary
+	Tables    []htmlTable
+	Timeline  []htmlEvent
+}
+
+// htmlSummary is a tile of the summary dashboard
+type htmlSummary struct {
+	Label  string
+	Count  int
+	Status eoldate.Status
+}
+
+// htmlTable is a table of cycles or check results of one product
+type htmlTable struct {
+	Name    string
+	Headers []string
+	Rows    [][]htmlCell
+}
+
+// htmlCell is a table cell, colored by its status and optionally linked
+type htmlCell struct {
+	Text   string
+	Status eoldate.Status
+	Link   string
+}
+
+// htmlEvent is an upcoming EOL date on the timeline
+type htmlEvent struct {
+	Date    string
+	Days    int
+	Label   string
+	Link    string
+	Status  eoldate.Status
+	Percent int
+}
+
+// writeProductsHTML writes the cycles of a product lookup as an HTML report
+func writeProductsHTML(w io.Writer, r *productReport) error {
+	tb := r.table
+	report := htmlReport{Title: "End of life report: " + r.name, Generated: tb.now.Format("2006-01-02")}
+
+	headers := make([]string, 0, len(tb.headers))
+	linkColumn := -1
+	for i, header := range tb.headers {
+		if header == "link" {
+			linkColumn = i
+			continue
+		}
+		headers = append(headers, header)
+	}
+	table := htmlTable{Name: r.name, Headers: headers}
+	counts := make(map[eoldate.Status]int)
+	for i, row := range tb.rows {
+		product := r.products[i]
+		cells := make([]htmlCell, 0, len(headers))
+		for j, value := range row {
+			if j == linkColumn {
+				continue
+			}
+			cell := htmlCell{Text: value, Status: tb.cellStatus(tb.headers[j], value)}
+			if strings.EqualFold(tb.headers[j], "cycle") {
+				cell.Link = product.Link
+			}
+			cells = append(cells, cell)
+		}
+		table.Rows = append(table.Rows, cells)
+
+		annotation := product.AnnotationWithin(tb.now, tb.warnDays)
+		counts[annotation.Status]++
+		if annotation.DaysToEOL != nil && *annotation.DaysToEOL >= 0 {
+			report.Timeline = append(report.Timeline, htmlEvent{
+				Date:   product.EOL.(string),
+				Days:   *annotation.DaysToEOL,
+				Label:  r.name + " " + product.Cycle,
+				Link:   product.Link,
+				Status: annotation.Status,
+			})
+		}
+	}
+	report.Tables = []htmlTable{table}
+	report.Summary = htmlSummaries("cycles", len(r.products), counts)
+	return writeHTML(w, report)
+}
+
+// writeCheckHTML writes check results as an HTML report with one table per product
+func writeCheckHTML(w io.Writer, r *checkReport) error {
+	report := htmlReport{Title: "End of life check report", Generated: r.now.Format("2006-01-02")}
+	headers := checkReportColumns(r.results)
+
+	counts := make(map[eoldate.Status]int)
+	tables := make(map[string]*htmlTable)
+	var names []string
+	for _, result := range r.results {
+		counts[result.Status]++
+		table, ok := tables[result.Product]
+		if !ok {
+			table = &htmlTable{Name: result.Product, Headers: headers[1:]}
+			tables[result.Product] = table
+			names = append(names, result.Product)
+		}
+		row := checkReportRow(result, len(headers))[1:]
+		cells := make([]htmlCell, len(row))
+		for i, value := range row {
+			cells[i] = htmlCell{Text: value}
+			switch headers[i+1] {
+			case "status":
+				cells[i].Status = result.Status
+			case "cycle":
+				cells[i].Link = result.Link
+			}
+		}
+		table.Rows = append(table.Rows, cells)
+
+		if result.DaysUntilEOL != nil && *result.DaysUntilEOL >= 0 {
+			report.Timeline = append(report.Timeline, htmlEvent{
+				Date:   result.EOLDate,
+				Days:   *result.DaysUntilEOL,
+				Label:  result.Product + " " + result.Version,
+				Link:   result.Link,
+				Status: result.Status,
+			})
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		report.Tables = append(report.Tables, *tables[name])
+	}
+	report.Summary = htmlSummaries("versions checked", len(r.results), counts)
+	return writeHTML(w, report)
+}
+
+// htmlSummaries returns the dashboard tiles for a total and the number of items per status
+func htmlSummaries(label string, total int, counts map[eoldate.Status]int) []htmlSummary {
+	return []htmlSummary{
+		{Label: label, Count: total},
+		{Label: "supported", Count: counts[eoldate.StatusSupported], Status: eoldate.StatusSupported},
+		{Label: "approaching EOL", Count: counts[eoldate.StatusApproachingEOL], Status: eoldate.StatusApproachingEOL},
+		{Label: "EOL", Count: counts[eoldate.StatusEOL], Status: eoldate.StatusEOL},
+		{Label: "unknown", Count: counts[eoldate.StatusUnknown], Status: eoldate.StatusUnknown},
+	}
+}
+
+// writeHTML sorts the timeline by date, scales its bars to the furthest date and renders the report
+func writeHTML(w io.Writer, report htmlReport) error {
+	sort.SliceStable(report.Timeline, func(i, j int) bool { return report.Timeline[i].Days < report.Timeline[j].Days })
+	maxDays := 1
+	for _, event := range report.Timeline {
+		if event.Days > maxDays {
+			maxDays = event.Days
+		}
+	}
+	for i := range report.Timeline {
+		report.Timeline[i].Percent = 1 + report.Timeline[i].Days*99/maxDays
+	}
+	return htmlReportTmpl.Execute(w, report)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mr-pmillz/eoldate"
+)
+
+func TestWriteCheckHTML(t *testing.T) {
+	var buf bytes.Buffer
+	report := &checkReport{results: checkResultsFixture(), now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	if err := writeCheckHTML(&buf, report); err != nil {
+		t.Fatalf("writeCheckHTML() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"<title>End of life check report</title>",
+		"Generated 2025-01-01",
+		`<div class="tile status-eol"><div class="count">1</div><div class="label">EOL</div></div>`,
+		`<td class="status-eol">eol</td>`,
+		`<a href="https://www.php.net/ChangeLog-7.php#7.4.33">7.4</a>`,
+		"php 8.1.30 &middot; 19 days",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("writeCheckHTML() does not contain %q", want)
+		}
+	}
+	// the timeline only has upcoming EOL dates, the soonest first
+	if strings.Contains(out, "-765 days") || strings.Index(out, "19 days") > strings.Index(out, "1094 days") {
+		t.Errorf("writeCheckHTML() timeline is not the upcoming dates in order")
+	}
+}
+
+func TestWriteProductsHTML(t *testing.T) {
+	var products eoldate.Products
+	if err := json.Unmarshal([]byte(phpFixture), &products); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	report := &productReport{name: "php", products: products, table: NewTableBuilder(products).SetNow(now)}
+	if err := writeProductsHTML(&buf, report); err != nil {
+		t.Fatalf("writeProductsHTML() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"<title>End of life report: php</title>",
+		"Generated 2025-01-01",
+		`<div class="count">3</div><div class="label">cycles</div>`,
+		`<div class="tile status-approaching-eol"><div class="count">1</div>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("writeProductsHTML() does not contain %q", want)
+		}
+	}
+}
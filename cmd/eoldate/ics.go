@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/mr-pmillz/eoldate"
+)
+
+// defaultRemindDays is how many days before an event the calendar reminder fires
+const defaultRemindDays = 30
+
+// icsEvent is an all day calendar event for a lifecycle date of a cycle
+type icsEvent struct {
+	uid         string
+	date        time.Time
+	summary     string
+	description string
+	link        string
+}
+
+// icsPhases are the lifecycle dates of a cycle exported as events
+var icsPhases = []struct {
+	kind    string
+	summary string
+	date    func(p eoldate.Product) interface{}
+}{
+	{kind: "support", summary: "end of active support", date: func(p eoldate.Product) interface{} { return p.Support }},
+	{kind: "eol", summary: "end of life", date: func(p eoldate.Product) interface{} { return p.EOL }},
+	{kind: "extended-support", summary: "end of extended support", date: func(p eoldate.Product) interface{} { return p.ExtendedSupport }},
+}
+
+// writeProductsICS writes the upcoming support, EOL and extended support dates of cycles as an iCalendar file
+func writeProductsICS(w io.Writer, r *productReport) error {
+	var events []icsEvent
+	for _, product := range r.products {
+		for _, phase := range icsPhases {
+			value, ok := phase.date(product).(string)
+			if !ok {
+				continue
+			}
+			date, err := eoldate.ParseDate(value)
+			if err != nil || date.Before(startOfDay(r.table.now)) {
+				continue
+			}
+			events = append(events, icsEvent{
+				uid:         icsUID(r.name, product.Cycle, phase.kind),
+				date:        date,
+				summary:     fmt.Sprintf("%s %s %s", r.name, product.Cycle, phase.summary),
+				description: fmt.Sprintf("%s %s (latest %s) reaches %s on %s.", r.name, product.Cycle, product.Latest, phase.summary, value),
+				link:        product.Link,
+			})
+		}
+	}
+	return writeICS(w, events, r.remindDays)
+}
+
+// writeCheckICS writes the upcoming EOL dates of checked versions as an iCalendar file
+func writeCheckICS(w io.Writer, r *checkReport) error {
+	var events []icsEvent
+	seen := make(map[string]bool)
+	for _, result := range r.results {
+		if result.DaysUntilEOL == nil || *result.DaysUntilEOL < 0 {
+			continue
+		}
+		date, err := eoldate.ParseDate(result.EOLDate)
+		if err != nil {
+			continue
+		}
+		// versions of the same cycle share one event
+		uid := icsUID(result.Product, result.Cycle, "eol")
+		if seen[uid] {
+			continue
+		}
+		seen[uid] = true
+		description := fmt.Sprintf("%s %s (cycle %s) reaches end of life on %s.", result.Product, result.Version, result.Cycle, result.EOLDate)
+		if result.RecommendedUpgrade != "" {
+			description += fmt.Sprintf(" Upgrade to %s.", result.RecommendedUpgrade)
+		}
+		events = append(events, icsEvent{
+			uid:         uid,
+			date:        date,
+			summary:     fmt.Sprintf("%s %s end of life", result.Product, result.Cycle),
+			description: description,
+			link:        result.Link,
+		})
+	}
+	return writeICS(w, events, r.remindDays)
+}
+
+// writeICS writes events as an RFC 5545 calendar with a reminder remindDays before each event
+func writeICS(w io.Writer, events []icsEvent, remindDays int) error {
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	var sb strings.Builder
+	line := func(s string) {
+		sb.WriteString(foldICSLine(s))
+		sb.WriteString("\r\n")
+	}
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//mr-pmillz//eoldate " + eoldate.CurrentVersion + "//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:End of life dates")
+	for _, event := range events {
+		line("BEGIN:VEVENT")
+		line("UID:" + event.uid)
+		line("DTSTAMP:" + stamp)
+		line("DTSTART;VALUE=DATE:" + event.date.Format("20060102"))
+		line("DTEND;VALUE=DATE:" + event.date.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY:" + escapeICSText(event.summary))
+		line("DESCRIPTION:" + escapeICSText(event.description))
+		if event.link != "" {
+			line("URL:" + event.link)
+		}
+		line("TRANSP:TRANSPARENT")
+		if remindDays > 0 {
+			line("BEGIN:VALARM")
+			line("ACTION:DISPLAY")
+			line("DESCRIPTION:" + escapeICSText(event.summary))
+			line(fmt.Sprintf("TRIGGER:-P%dD", remindDays))
+			line("END:VALARM")
+		}
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// icsUID returns an event UID that stays the same across exports so calendars update events instead of duplicating them
+func icsUID(product, cycle, kind string) string {
+	id := strings.ToLower(fmt.Sprintf("%s-%s-%s", product, cycle, kind))
+	id = strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '.' {
+			return r
+		}
+		return '-'
+	}, id)
+	return id + "@eoldate"
+}
+
+// escapeICSText escapes a TEXT property value
+func escapeICSText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// foldICSLine splits content lines longer than 75 octets, continuing them with a leading space
+func foldICSLine(s string) string {
+	const limit = 75
+	if len(s) <= limit {
+		return s
+	}
+	var sb strings.Builder
+	width := 0
+	for _, r := range s {
+		size := len(string(r))
+		if width+size > limit {
+			sb.WriteString("\r\n ")
+			width = 1
+		}
+		sb.WriteRune(r)
+		width += size
+	}
+	return sb.String()
+}
+
+// startOfDay returns midnight of the day of t
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mr-pmillz/eoldate"
+)
+
+// icsUIDs returns the UID properties of a calendar in order
+func icsUIDs(calendar string) []string {
+	return regexp.MustCompile(`(?m)^UID:(.*)\r$`).FindAllString(calendar, -1)
+}
+
+func TestWriteProductsICS(t *testing.T) {
+	var products eoldate.Products
+	if err := json.Unmarshal([]byte(phpFixture), &products); err != nil {
+		t.Fatal(err)
+	}
+	report := &productReport{name: "php", products: products, table: NewTableBuilder(products).SetNow(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)), remindDays: 30}
+	render := func() string {
+		var buf bytes.Buffer
+		if err := writeProductsICS(&buf, report); err != nil {
+			t.Fatalf("writeProductsICS() error = %v", err)
+		}
+		return buf.String()
+	}
+
+	first := render()
+	second := render()
+	uids := icsUIDs(first)
+	want := []string{"UID:php-8.3-support@eoldate\r", "UID:php-8.3-eol@eoldate\r", "UID:php-8.1-eol@eoldate\r"}
+	if strings.Join(uids, ",") != strings.Join(want, ",") {
+		t.Errorf("UIDs = %q, want the upcoming dates %q", uids, want)
+	}
+	if strings.Join(icsUIDs(second), ",") != strings.Join(uids, ",") {
+		t.Errorf("UIDs changed between exports: %q and %q", uids, icsUIDs(second))
+	}
+
+	if !strings.HasPrefix(first, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n") || !strings.HasSuffix(first, "END:VCALENDAR\r\n") {
+		t.Errorf("writeProductsICS() = %q, want a CRLF terminated VCALENDAR", first)
+	}
+	if strings.Count(first, "\n") != strings.Count(first, "\r\n") {
+		t.Errorf("writeProductsICS() has bare LF line endings")
+	}
+	for _, want := range []string{"DTSTART;VALUE=DATE:20250120\r\n", "DTEND;VALUE=DATE:20250121\r\n", "TRIGGER:-P30D\r\n", "URL:https://www.php.net/ChangeLog-8.php#8.1.30\r\n"} {
+		if !strings.Contains(first, want) {
+			t.Errorf("writeProductsICS() does not contain %q", want)
+		}
+	}
+}
+
+func TestWriteCheckICS(t *testing.T) {
+	results := checkResultsFixture()
+	// a second version of the same cycle shares its event
+	duplicate := results[1]
+	duplicate.Version = "8.1.29"
+	results = append(results, duplicate)
+	var buf bytes.Buffer
+	if err := writeCheckICS(&buf, &checkReport{results: results}); err != nil {
+		t.Fatalf("writeCheckICS() error = %v", err)
+	}
+	want := []string{"UID:php-8.1-eol@eoldate\r", "UID:php-8.3-eol@eoldate\r"}
+	if got := icsUIDs(buf.String()); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("UIDs = %q, want %q", got, want)
+	}
+	if strings.Contains(buf.String(), "BEGIN:VALARM") {
+		t.Errorf("writeCheckICS() without remind days has an alarm")
+	}
+}
+
+func TestFoldICSLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{name: "short", line: "SUMMARY:php 8.1 end of life"},
+		{name: "exactly 75 octets", line: "DESCRIPTION:" + strings.Repeat("a", 63)},
+		{name: "long", line: "DESCRIPTION:" + strings.Repeat("php 8.1 reaches end of life\\, upgrade to 8.3. ", 6)},
+		{name: "multi-byte runes are not split", line: "SUMMARY:" + strings.Repeat("é", 40) + strings.Repeat("日本", 30)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			folded := foldICSLine(tt.line)
+			lines := strings.Split(folded, "\r\n")
+			if len(tt.line) <= 75 && len(lines) != 1 {
+				t.Errorf("foldICSLine() folded a line of %d octets", len(tt.line))
+			}
+			for i, line := range lines {
+				if len(line) > 75 {
+					t.Errorf("line %d has %d octets, want at most 75", i, len(line))
+				}
+				if i > 0 && !strings.HasPrefix(line, " ") {
+					t.Errorf("continuation line %d = %q, want a leading space", i, line)
+				}
+				if !utf8.ValidString(line) {
+					t.Errorf("line %d = %q splits a rune", i, line)
+				}
+			}
+			if unfolded := strings.ReplaceAll(folded, "\r\n ", ""); unfolded != tt.line {
+				t.Errorf("unfolded = %q, want %q", unfolded, tt.line)
+			}
+		})
+	}
+}
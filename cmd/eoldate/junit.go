@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/mr-pmillz/eoldate"
+)
+
+// how -format junit reports versions approaching EOL
+const (
+	junitSkipped = "skipped"
+	junitFailure = "failure"
+	junitPassed  = "passed"
+)
+
+// junitTestSuites is the root element of a JUnit XML report
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+}
+
+// writeJUnit writes check results as a JUnit XML report with one test case per checked version.
+// EOL versions fail, lookup errors are errors and versions approaching EOL are skipped, failed or passed as selected by approaching
+func writeJUnit(w io.Writer, results []eoldate.CheckResult, approaching string) error {
+	switch approaching {
+	case junitSkipped, junitFailure, junitPassed:
+	default:
+		return fmt.Errorf("invalid -junit-approaching %q, choose one of: %s, %s, %s", approaching, junitSkipped, junitFailure, junitPassed)
+	}
+
+	suite := junitTestSuite{Name: "eoldate", Tests: len(results)}
+	for _, result := range results {
+		tc := junitTestCase{
+			Name:      fmt.Sprintf("%s %s", result.Product, result.Version),
+			ClassName: "eoldate." + result.Product,
+			File:      result.Source,
+			Line:      result.Line,
+		}
+		msg := checkResultMessage(result)
+		switch result.Status {
+		case eoldate.StatusEOL:
+			tc.Failure = &junitMessage{Message: msg, Type: string(result.Status)}
+			suite.Failures++
+		case eoldate.StatusApproachingEOL:
+			switch approaching {
+			case junitFailure:
+				tc.Failure = &junitMessage{Message: msg, Type: string(result.Status)}
+				suite.Failures++
+			case junitPassed:
+				tc.SystemOut = msg
+			default:
+				tc.Skipped = &junitMessage{Message: msg}
+				suite.Skipped++
+			}
+		case eoldate.StatusUnknown:
+			tc.Error = &junitMessage{Message: msg, Type: string(result.Status)}
+			suite.Errors++
+		default:
+			if result.EOLDate != "" {
+				tc.SystemOut = fmt.Sprintf("supported until %s", result.EOLDate)
+			}
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	report := junitTestSuites{
+		Name:     "eoldate",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Errors:   suite.Errors,
+		Skipped:  suite.Skipped,
+		Suites:   []junitTestSuite{suite},
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
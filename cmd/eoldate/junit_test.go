@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestWriteJUnit(t *testing.T) {
+	tests := []struct {
+		approaching  string
+		wantFailures int
+		wantSkipped  int
+		wantErr      bool
+	}{
+		{approaching: junitSkipped, wantFailures: 1, wantSkipped: 1},
+		{approaching: junitFailure, wantFailures: 2},
+		{approaching: junitPassed, wantFailures: 1},
+		{approaching: "ignored", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.approaching, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := writeJUnit(&buf, checkResultsFixture(), tt.approaching)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("writeJUnit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !strings.HasPrefix(buf.String(), xml.Header) {
+				t.Errorf("writeJUnit() = %q, want an XML header", buf.String())
+			}
+			var report junitTestSuites
+			if err = xml.Unmarshal(buf.Bytes(), &report); err != nil {
+				t.Fatalf("writeJUnit() = %s, not XML: %v", buf.String(), err)
+			}
+			if report.Tests != 4 || report.Failures != tt.wantFailures || report.Errors != 1 || report.Skipped != tt.wantSkipped {
+				t.Errorf("testsuites tests=%d failures=%d errors=%d skipped=%d, want 4, %d, 1, %d",
+					report.Tests, report.Failures, report.Errors, report.Skipped, tt.wantFailures, tt.wantSkipped)
+			}
+			if len(report.Suites) != 1 || report.Suites[0].Tests != report.Tests || report.Suites[0].Failures != report.Failures {
+				t.Fatalf("testsuite = %+v, want the totals of the report", report.Suites)
+			}
+
+			cases := report.Suites[0].TestCases
+			eol := cases[0]
+			if eol.Name != "php 7.4.33" || eol.ClassName != "eoldate.php" || eol.File != "docker/Dockerfile" || eol.Line != 3 {
+				t.Errorf("EOL test case = %+v", eol)
+			}
+			if eol.Failure == nil || eol.Failure.Type != "eol" || eol.Failure.Message != "php 7.4.33 (cycle 7.4) reached end of life on 2022-11-28. Upgrade to 8.3.12." {
+				t.Errorf("EOL failure = %+v", eol.Failure)
+			}
+			if cases[2].Failure != nil || cases[2].SystemOut != "supported until 2027-12-31" {
+				t.Errorf("supported test case = %+v", cases[2])
+			}
+			if cases[3].Error == nil || cases[3].Error.Message != "nope 1.0: product not found: nope." {
+				t.Errorf("unknown test case error = %+v", cases[3].Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mr-pmillz/eoldate"
+	"github.com/olekukonko/tablewriter"
+	"github.com/projectdiscovery/gologger"
+)
+
+// listing is a row of the list command
+type listing struct {
+	Name            string `json:"name"`
+	Label           string `json:"label,omitempty"`
+	Category        string `json:"category,omitempty"`
+	LatestCycle     string `json:"latestCycle,omitempty"`
+	Latest          string `json:"latest,omitempty"`
+	SupportedCycles *int   `json:"supportedCycles,omitempty"`
+}
+
+// runList prints all technologies known to endoflife.date
+func runList(g *globalOptions, args []string) int {
+	fs := g.newFlagSet("list", "eoldate list [flags]")
+	filter := fs.String("filter", "", "only list technologies whose name, label or alias contains this text")
+	category := fs.String("category", "", "only list technologies in this category, e.g. lang, os, database, framework")
+	details := fs.Bool("details", false, "fetch each listed technology to show its latest cycle and number of supported cycles")
+	concurrency := fs.Int("concurrency", 8, "number of technologies to fetch in parallel with -details")
+	if err := g.parseFlags(fs, args); err != nil {
+		return flagExitCode(err, exitUsage)
+	}
+	format := g.format
+	if format == "" && *details {
+		format = "table"
+	}
+
+	client := g.client()
+	listings, err := listProducts(client, format != "" || *category != "", *filter, *category)
+	if err != nil {
+		gologger.Error().Msg(err.Error())
+		return exitError
+	}
+
+	if *details {
+		names := make([]string, len(listings))
+		for i, l := range listings {
+			names[i] = l.Name
+		}
+		products, errs := client.GetProducts(names, *concurrency)
+		for name, err := range errs {
+			gologger.Error().Msgf("Failed to fetch %s: %v", name, err)
+		}
+		now := g.now()
+		for i := range listings {
+			data, ok := products[listings[i].Name]
+			if !ok {
+				continue
+			}
+			if latest := data.LatestCycle(); latest != nil {
+				listings[i].LatestCycle = latest.Cycle
+				listings[i].Latest = latest.Latest
+			}
+			supported := len(data.SupportedCycles(now))
+			listings[i].SupportedCycles = &supported
+		}
+	}
+
+	if g.tmpl != nil {
+		err = g.executeTemplate(os.Stdout, templateData{Listings: listings})
+	} else {
+		err = writeListings(os.Stdout, format, listings, *details, g.color())
+	}
+	if err != nil {
+		gologger.Error().Msg(err.Error())
+		return exitUsage
+	}
+	return exitOK
+}
+
+// listProducts returns the technologies matching filter and category.
+// Metadata from the v1 API is only fetched when needed, falling back to plain names if it is unavailable
+func listProducts(client *eoldate.Client, withMetadata bool, filter, category string) ([]listing, error) {
+	var listings []listing
+	if withMetadata {
+		infos, err := client.GetProductsInfo()
+		switch {
+		case err == nil:
+			for _, info := range infos {
+				if filter != "" && !info.Matches(filter) {
+					continue
+				}
+				if category != "" && !strings.EqualFold(info.Category, category) {
+					continue
+				}
+				listings = append(listings, listing{Name: info.Name, Label: info.Label, Category: info.Category})
+			}
+			return listings, nil
+		case category != "":
+			return nil, fmt.Errorf("filtering by category requires product metadata: %w", err)
+		default:
+			gologger.Info().Msgf("Product metadata unavailable, listing names only: %v", err)
+		}
+	}
+
+	gologger.Info().Msg("Getting all available technologies")
+	allProducts, err := client.GetAllProducts()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range allProducts {
+		if filter != "" && !strings.Contains(strings.ToLower(name), strings.ToLower(filter)) {
+			continue
+		}
+		listings = append(listings, listing{Name: name})
+	}
+	return listings, nil
+}
+
+// writeListings writes the list command output, one name per line unless a format is selected
+func writeListings(w io.Writer, format string, listings []listing, details, color bool) error {
+	headers := []string{"name", "label", "category"}
+	if details {
+		headers = append(headers, "latest cycle", "latest", "supported cycles")
+	}
+	rows := make([][]string, len(listings))
+	for i, l := range listings {
+		rows[i] = []string{l.Name, l.Label, l.Category}
+		if details {
+			supported := ""
+			if l.SupportedCycles != nil {
+				supported = strconv.Itoa(*l.SupportedCycles)
+			}
+			rows[i] = append(rows[i], l.LatestCycle, l.Latest, supported)
+		}
+	}
+
+	switch format {
+	case "":
+		for _, l := range listings {
+			if _, err := fmt.Fprintln(w, l.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "json":
+		return writeJSONIndent(w, listings)
+	case "jsonl":
+		return writeJSONLines(w, len(listings), func(i int) interface{} { return listings[i] })
+	case "yaml":
+		return writeYAML(w, listings)
+	case "markdown":
+		return writeMarkdownTable(w, headers, rows)
+	case "csv", "tsv":
+		cw := csv.NewWriter(w)
+		if format == "tsv" {
+			cw.Comma = '\t'
+		}
+		if err := cw.Write(headers); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(rows); err != nil {
+			return err
+		}
+		return cw.Error()
+	case "table":
+		var buf strings.Builder
+		table := tablewriter.NewWriter(&buf)
+		table.SetHeader(headers)
+		table.SetAutoWrapText(false)
+		table.SetAutoFormatHeaders(true)
+		table.SetAlignment(tablewriter.ALIGN_LEFT)
+		if color {
+			headerColors := make([]tablewriter.Colors, len(headers))
+			for i := range headerColors {
+				headerColors[i] = tablewriter.Colors{tablewriter.Bold, tablewriter.FgHiYellowColor, tablewriter.BgBlackColor}
+			}
+			table.SetHeaderColor(headerColors...)
+		}
+		table.AppendBulk(rows)
+		table.Render()
+		_, err := io.WriteString(w, buf.String())
+		return err
+	default:
+		return fmt.Errorf("format %q does not support the list command", format)
+	}
+}
@@ -1,292 +1,239 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
-	"github.com/olekukonko/tablewriter"
 	"os"
-	"reflect"
-	"sort"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/mr-pmillz/eoldate"
-	"github.com/projectdiscovery/gologger"
 )
 
-func main() {
-	tech := flag.String("t", "", "technology/software name to lookup")
-	output := flag.String("o", "", "output directory to save results to")
-	version := flag.Bool("version", false, "show version and exit")
-	getAll := flag.Bool("getall", false, "get all results from all technologies")
-	flag.Parse()
+// exit codes shared by all subcommands
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
 
-	eolOptions := eoldate.Options{
-		Tech:    *tech,
-		Output:  *output,
-		Version: *version,
-		GetAll:  *getAll,
-	}
+// command is a subcommand of the eoldate CLI
+type command struct {
+	name    string
+	summary string
+	run     func(g *globalOptions, args []string) int
+}
 
-	if eolOptions.Version {
-		fmt.Printf("Version: %s\n", eoldate.CurrentVersion)
-		os.Exit(0)
+// commands returns all subcommands in the order they are listed in the usage
+func commands() []command {
+	return []command{
+		{name: "product", summary: "show the release cycles of a technology", run: runProduct},
+		{name: "check", summary: "check installed versions against their end of life dates", run: runCheck},
+		{name: "list", summary: "list all technologies known to endoflife.date", run: runList},
+		{name: "cache", summary: "inspect or clear the local API response cache", run: runCache},
+		{name: "scan", summary: "detect and check versions used in a directory", run: runScan},
+		{name: "diff", summary: "show how the lifecycle data of a technology changed upstream", run: runDiff},
+		{name: "watch", summary: "report lifecycle changes of technologies as they happen", run: runWatch},
+		{name: "export", summary: "export lifecycle data to a SQLite database", run: runExport},
+		{name: "serve", summary: "serve lookups and checks over HTTP", run: runServe},
+		{name: "version", summary: "show version and exit", run: runVersion},
 	}
+}
 
-	if eolOptions.Output != "" {
-		absOutputDir, err := eoldate.ResolveAbsPath(eolOptions.Output)
-		if err != nil {
-			gologger.Fatal().Msg(err.Error())
-		}
-		eolOptions.Output = absOutputDir
-		if err = os.MkdirAll(absOutputDir, 0755); err != nil {
-			gologger.Fatal().Msg(err.Error())
-		}
-	}
+// globalOptions are flags accepted by every subcommand, either before or after the command name
+type globalOptions struct {
+	format   string
+	cacheDir string
+	noColor  bool
+	asOf     string
+	asOfTime time.Time
+	// template and templateString select a text/template to render output with instead of -format
+	template       string
+	templateString string
+	tmpl           *template.Template
+}
 
-	client := eoldate.NewClient()
+// register adds the global flags to a flag set, keeping values that were already parsed
+func (g *globalOptions) register(fs *flag.FlagSet) {
+	fs.StringVar(&g.format, "format", g.format, fmt.Sprintf("output format: %s (default depends on the command)", strings.Join(formatNames(), ", ")))
+	fs.StringVar(&g.cacheDir, "cache-dir", g.cacheDir, "directory used to cache API responses (default ~/.config/eoldate/cache)")
+	fs.BoolVar(&g.noColor, "no-color", g.noColor, "disable colored output, also disabled by NO_COLOR and when stdout is not a terminal")
+	fs.StringVar(&g.asOf, "as-of", g.asOf, "evaluate support status as of this date (YYYY-MM-DD) instead of today")
+	fs.StringVar(&g.template, "template", g.template, "render output with this Go text/template file instead of -format")
+	fs.StringVar(&g.templateString, "template-string", g.templateString, "render output with this inline Go text/template instead of -format")
+}
 
-	if eolOptions.GetAll {
-		gologger.Info().Msg("Getting all available technologies")
-		allProducts, err := client.GetAllProducts()
+// parseFlags parses the arguments of a subcommand and validates the global flags
+func (g *globalOptions) parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if g.asOf != "" {
+		asOf, err := time.Parse("2006-01-02", g.asOf)
 		if err != nil {
-			gologger.Fatal().Msg(err.Error())
+			err = fmt.Errorf("invalid -as-of date %q, expected YYYY-MM-DD", g.asOf)
+			fmt.Fprintln(fs.Output(), err)
+			fs.Usage()
+			return err
 		}
-		fmt.Println(allProducts)
-		os.Exit(0)
+		g.asOfTime = asOf
 	}
-
-	if eolOptions.Tech == "" {
-		gologger.Info().Msg("No technologies specified")
-		flag.Usage()
-		os.Exit(1)
-	}
-
-	data, err := client.GetProduct(eolOptions.Tech)
+	tmpl, err := g.parseTemplate()
 	if err != nil {
-		gologger.Fatal().Msgf("Error fetching product data: %v", err)
-	}
-
-	tableBuilder := NewTableBuilder(data)
-	tableString := tableBuilder.Render()
-	fmt.Println(tableString)
-
-	if eolOptions.Output != "" {
-		writeOutputFiles(eolOptions, tableString, data)
+		err = fmt.Errorf("invalid template: %w", err)
+		fmt.Fprintln(fs.Output(), err)
+		return err
 	}
+	g.tmpl = tmpl
+	return nil
 }
 
-func writeOutputFiles(options eoldate.Options, tableString string, products []eoldate.Product) {
-	files := map[string]func() error{
-		fmt.Sprintf("%s/%s.txt", options.Output, options.Tech): func() error {
-			return eoldate.WriteStringToFile(fmt.Sprintf("%s/%s.txt", options.Output, options.Tech), tableString)
-		},
-		fmt.Sprintf("%s/%s.json", options.Output, options.Tech): func() error {
-			return eoldate.WriteStructToJSONFile(products, fmt.Sprintf("%s/%s.json", options.Output, options.Tech))
-		},
-		fmt.Sprintf("%s/%s.csv", options.Output, options.Tech): func() error {
-			return eoldate.WriteStructToCSVFile(products, fmt.Sprintf("%s/%s.csv", options.Output, options.Tech))
-		},
-	}
-
-	for filename, writeFunc := range files {
-		if err := writeFunc(); err != nil {
-			gologger.Error().Msgf("Failed to write %s: %v", filename, err)
-		}
+// now returns the -as-of date or the current time
+func (g *globalOptions) now() time.Time {
+	if !g.asOfTime.IsZero() {
+		return g.asOfTime
 	}
+	return time.Now()
 }
 
-// TableBuilder handles the creation and population of the table
-type TableBuilder struct {
-	products []eoldate.Product
-	headers  []string
-	rows     [][]string
-}
-
-// NewTableBuilder creates a new TableBuilder instance
-func NewTableBuilder(products []eoldate.Product) *TableBuilder {
-	tb := &TableBuilder{products: products}
-	tb.determineHeaders()
-	tb.buildRows()
-	return tb
-}
-
-// determineHeaders identifies all unique keys across all products
-func (tb *TableBuilder) determineHeaders() {
-	headerSet := make(map[string]bool)
-	for _, product := range tb.products {
-		v := reflect.ValueOf(product)
-		t := v.Type()
-		for i := 0; i < v.NumField(); i++ {
-			field := t.Field(i)
-			if field.Name != "AdditionalFields" {
-				tag := field.Tag.Get("json")
-				if tag != "" && tag != "-" {
-					headerName := strings.Split(tag, ",")[0]
-					if !isEmptyValue(v.Field(i).Interface()) {
-						headerSet[headerName] = true
-					}
-				}
-			}
-		}
+// color reports whether stdout should be colored.
+// Colors are disabled by -no-color, by a non-empty NO_COLOR environment variable and when stdout is not a terminal
+func (g *globalOptions) color() bool {
+	if g.noColor || os.Getenv("NO_COLOR") != "" {
+		return false
 	}
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
 
-	tb.headers = make([]string, 0, len(headerSet))
-	for header := range headerSet {
-		if header != "" {
-			tb.headers = append(tb.headers, header)
+// client returns an API client honoring the global flags
+func (g *globalOptions) client() *eoldate.Client {
+	var opts []eoldate.ClientOption
+	if g.cacheDir != "" {
+		if absCacheDir, err := eoldate.ResolveAbsPath(g.cacheDir); err == nil {
+			opts = append(opts, eoldate.WithCacheDir(absCacheDir))
 		}
 	}
-	sort.Strings(tb.headers)
+	return eoldate.NewClient(opts...)
 }
 
-// isEmptyValue checks if a value is considered empty
-func isEmptyValue(v interface{}) bool {
-	if v == nil {
-		return true
-	}
-	switch value := v.(type) {
-	case string:
-		return value == ""
-	case bool:
-		return !value
-	case int, int8, int16, int32, int64:
-		return value == 0
-	case float32, float64:
-		return value == 0
-	case []interface{}:
-		return len(value) == 0
-	case map[string]interface{}:
-		return len(value) == 0
-	case *float64:
-		return value == nil
-	default:
-		return false
+// newFlagSet creates the flag set of a subcommand with the global flags and a usage line
+func (g *globalOptions) newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	g.register(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s\n\nFlags:\n", usage)
+		fs.PrintDefaults()
 	}
+	return fs
 }
 
-// buildRows constructs the rows for the table
-func (tb *TableBuilder) buildRows() {
-	for _, product := range tb.products {
-		row := make([]string, len(tb.headers))
-		v := reflect.ValueOf(product)
-		for i, header := range tb.headers {
-			value := ""
-			field := v.FieldByNameFunc(func(n string) bool {
-				f, _ := v.Type().FieldByName(n)
-				return strings.EqualFold(strings.Split(f.Tag.Get("json"), ",")[0], header)
-			})
-			if field.IsValid() {
-				value = tb.formatValue(field.Interface())
-			}
-			if value == "" || value == eoldate.NotAvailable {
-				if val, ok := product.AdditionalFields[header]; ok {
-					value = tb.formatValue(val)
-				}
-			}
-			row[i] = value
-		}
-		tb.rows = append(tb.rows, row)
+// flagExitCode maps a flag parsing error to an exit code, treating -h as success
+func flagExitCode(err error, code int) int {
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
 	}
+	return code
 }
 
-// formatValue converts an interface{} value to a string representation
-func (tb *TableBuilder) formatValue(v interface{}) string {
-	if v == nil {
-		return eoldate.NotAvailable
-	}
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
 
-	switch value := v.(type) {
-	case string:
-		return value
-	case float64:
-		if value == float64(int64(value)) {
-			return fmt.Sprintf("%.0f", value)
+// run parses the global flags and dispatches to a subcommand.
+// The legacy -t, -o, -getall and -version flags are kept as aliases of the product, list and version commands
+func run(args []string) int {
+	g := &globalOptions{}
+	fs := flag.NewFlagSet("eoldate", flag.ContinueOnError)
+	g.register(fs)
+	tech := fs.String("t", "", "technology/software name to lookup (alias of the product command)")
+	output := fs.String("o", "", "output directory to save results to (alias of the product command)")
+	version := fs.Bool("version", false, "show version and exit (alias of the version command)")
+	getAll := fs.Bool("getall", false, "get all results from all technologies (alias of the list command)")
+	fs.Usage = func() { printUsage(fs) }
+	if err := g.parseFlags(fs, args); err != nil {
+		return flagExitCode(err, exitUsage)
+	}
+
+	if fs.NArg() > 0 {
+		name := fs.Arg(0)
+		if name == "help" {
+			return runHelp(g, fs, fs.Args()[1:])
 		}
-		return fmt.Sprintf("%.2f", value)
-	case *float64:
-		if value == nil {
-			return eoldate.NotAvailable
+		for _, cmd := range commands() {
+			if cmd.name == name {
+				return cmd.run(g, fs.Args()[1:])
+			}
 		}
-		if *value == float64(int64(*value)) {
-			return fmt.Sprintf("%.0f", *value)
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+		printUsage(fs)
+		return exitUsage
+	}
+
+	switch {
+	case *version:
+		return runVersion(g, nil)
+	case *getAll:
+		return runList(g, nil)
+	case *tech != "":
+		productArgs := []string{"-t", *tech}
+		if *output != "" {
+			productArgs = append(productArgs, "-o", *output)
 		}
-		return fmt.Sprintf("%.2f", *value)
-	case bool:
-		return fmt.Sprintf("%t", value)
-	case time.Time:
-		return value.Format("2006-01-02")
-	case interface{}:
-		return fmt.Sprintf("%v", value)
+		return runProduct(g, productArgs)
 	default:
-		return fmt.Sprintf("%v", value)
+		printUsage(fs)
+		return exitUsage
 	}
 }
 
-// Render creates and renders the table
-func (tb *TableBuilder) Render() string {
-	var buf strings.Builder
-	table := tablewriter.NewWriter(&buf)
-	table.SetHeader(tb.headers)
-	table.SetAutoWrapText(false)
-	table.SetAutoFormatHeaders(true)
-	table.SetHeaderAlignment(tablewriter.ALIGN_CENTER)
-	table.SetAlignment(tablewriter.ALIGN_CENTER)
-
-	// Set header colors
-	headerColors := make([]tablewriter.Colors, len(tb.headers))
-	for i := range headerColors {
-		headerColors[i] = tablewriter.Colors{tablewriter.Bold, tablewriter.FgHiYellowColor, tablewriter.BgBlackColor}
-	}
-	table.SetHeaderColor(headerColors...)
-
-	for _, row := range tb.rows {
-		colors := tb.colorizeRow(row)
-		table.Rich(row, colors)
+// runHelp prints the usage of the given subcommand
+func runHelp(g *globalOptions, fs *flag.FlagSet, args []string) int {
+	if len(args) == 0 {
+		printUsage(fs)
+		return exitOK
 	}
-
-	table.Render()
-	return buf.String()
-}
-
-// colorizeRow applies color to specific columns based on their values
-func (tb *TableBuilder) colorizeRow(row []string) []tablewriter.Colors {
-	colors := make([]tablewriter.Colors, len(row))
-	for i, header := range tb.headers {
-		switch strings.ToLower(header) {
-		case "eol", "support", "extendedsupport":
-			colors[i] = tb.getDateColor(row[i])
+	for _, cmd := range commands() {
+		if cmd.name == args[0] {
+			return cmd.run(g, []string{"-h"})
 		}
 	}
-	return colors
+	fmt.Fprintf(os.Stderr, "unknown command %q\n\n", args[0])
+	printUsage(fs)
+	return exitUsage
 }
 
-// getDateColor returns the appropriate color based on the date value
-func (tb *TableBuilder) getDateColor(dateStr string) tablewriter.Colors {
-	date, err := tb.parseDate(dateStr)
-	if err != nil {
-		return tablewriter.Colors{}
-	}
-
-	if date.Before(time.Now()) {
-		return tablewriter.Colors{tablewriter.FgRedColor}
+// printUsage prints the top level usage with all subcommands
+func printUsage(fs *flag.FlagSet) {
+	out := fs.Output()
+	fmt.Fprintf(out, "Usage: eoldate [global flags] <command> [flags]\n\nCommands:\n")
+	for _, cmd := range commands() {
+		fmt.Fprintf(out, "  %-9s %s\n", cmd.name, cmd.summary)
 	}
-	return tablewriter.Colors{tablewriter.FgGreenColor}
+	fmt.Fprintf(out, "\nFlags:\n")
+	fs.PrintDefaults()
+	fmt.Fprintf(out, "\nRun 'eoldate help <command>' or 'eoldate <command> -h' for help on a command.\n")
 }
 
-// parseDate attempts to parse a date string in various formats
-func (tb *TableBuilder) parseDate(dateStr string) (time.Time, error) {
-	formats := []string{
-		"2006-01-02",
-		"2006-01",
-		"2006",
+// runVersion prints the version of eoldate
+func runVersion(g *globalOptions, args []string) int {
+	fs := g.newFlagSet("version", "eoldate version")
+	if err := g.parseFlags(fs, args); err != nil {
+		return flagExitCode(err, exitUsage)
 	}
+	fmt.Printf("Version: %s\n", eoldate.CurrentVersion)
+	return exitOK
+}
 
-	for _, format := range formats {
-		if date, err := time.Parse(format, dateStr); err == nil {
-			return date, nil
+// splitList splits a comma separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
 		}
 	}
-
-	return time.Time{}, fmt.Errorf("unable to parse date: %s", dateStr)
+	return items
 }
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mr-pmillz/eoldate"
+)
+
+// phpFixture has a supported, an approaching EOL as of 2025-01-01 and an EOL cycle
+const phpFixture = `[
+{"cycle":"8.3","releaseDate":"2023-11-23","eol":"2027-12-31","support":"2025-12-31","latest":"8.3.12","latestReleaseDate":"2024-09-26","link":"https://www.php.net/ChangeLog-8.php#8.3.12","lts":false},
+{"cycle":"8.1","releaseDate":"2021-11-25","eol":"2025-01-20","support":"2023-11-25","latest":"8.1.30","latestReleaseDate":"2024-09-26","link":"https://www.php.net/ChangeLog-8.php#8.1.30","lts":false},
+{"cycle":"7.4","releaseDate":"2019-11-28","eol":"2022-11-28","support":"2021-11-28","latest":"7.4.33","latestReleaseDate":"2022-11-03","link":"https://www.php.net/ChangeLog-7.php#7.4.33","lts":false}
+]`
+
+// checkResultsFixture returns check results of every status as of 2025-01-01, found by a scan
+func checkResultsFixture() []eoldate.CheckResult {
+	days := func(n int) *int { return &n }
+	return []eoldate.CheckResult{
+		{Product: "php", Version: "7.4.33", Status: eoldate.StatusEOL, Cycle: "7.4", EOLDate: "2022-11-28", DaysUntilEOL: days(-765), Latest: "7.4.33", RecommendedUpgrade: "8.3.12", Link: "https://www.php.net/ChangeLog-7.php#7.4.33", Source: "docker/Dockerfile", Line: 3},
+		{Product: "php", Version: "8.1.30", Status: eoldate.StatusApproachingEOL, Cycle: "8.1", EOLDate: "2025-01-20", DaysUntilEOL: days(19), Latest: "8.1.30", RecommendedUpgrade: "8.3.12", Source: "composer.json"},
+		{Product: "php", Version: "8.3.12", Status: eoldate.StatusSupported, Cycle: "8.3", EOLDate: "2027-12-31", DaysUntilEOL: days(1094), Latest: "8.3.12", Source: "go.mod", Line: 1},
+		{Product: "nope", Version: "1.0", Status: eoldate.StatusUnknown, Error: "product not found: nope", Source: ".tool-versions", Line: 2},
+	}
+}
+
+// writeCacheFixture writes today's cache files of products to a temporary cache directory,
+// so commands run without the endoflife.date API
+func writeCacheFixture(t *testing.T, products map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	timestamp := time.Now().Format("01-02-2006")
+	var names []string
+	for name, data := range products {
+		names = append(names, name)
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("%s-%s.json", name, timestamp)), []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	all := strings.Join(names, "\n") + "\n"
+	if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("all-technologies-%s.json", timestamp)), []byte(all), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// captureStdout runs fn with os.Stdout redirected and returns what it wrote
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	done := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- data
+	}()
+	defer func() { os.Stdout = stdout }()
+	fn()
+	_ = w.Close()
+	return string(<-done)
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mr-pmillz/eoldate"
+)
+
+// markdownBadges are the text badges shown for each status, readable without emoji support
+var markdownBadges = map[eoldate.Status]string{
+	eoldate.StatusSupported:      "`SUPPORTED`",
+	eoldate.StatusApproachingEOL: "`APPROACHING EOL`",
+	eoldate.StatusEOL:            "`EOL`",
+	eoldate.StatusUnknown:        "`UNKNOWN`",
+}
+
+// markdownBadge returns the text badge of a status
+func markdownBadge(status eoldate.Status) string {
+	if badge, ok := markdownBadges[status]; ok {
+		return badge
+	}
+	return markdownBadges[eoldate.StatusUnknown]
+}
+
+// writeProductsMarkdown writes the cycles of a product lookup for a pull request comment.
+// Cycles that have not reached EOL are listed first and the full cycle history is collapsed below them
+func writeProductsMarkdown(w io.Writer, r *productReport) error {
+	tb := r.table
+	var current [][]string
+	counts := make(map[eoldate.Status]int)
+	for i, product := range r.products {
+		status := product.AnnotationWithin(tb.now, tb.warnDays).Status
+		counts[status]++
+		if status != eoldate.StatusEOL {
+			current = append(current, tb.rows[i])
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "### %s\n\n", r.name)
+	sb.WriteString(markdownSummary(counts))
+	if len(current) > 0 {
+		if err := writeMarkdownTable(&sb, tb.headers, markdownBadgeRows(tb.headers, current)); err != nil {
+			return err
+		}
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(&sb, "<details>\n<summary>Full cycle history (%d cycles)</summary>\n\n", len(tb.rows))
+	if err := writeMarkdownTable(&sb, tb.headers, markdownBadgeRows(tb.headers, tb.rows)); err != nil {
+		return err
+	}
+	sb.WriteString("\n</details>\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// writeCheckMarkdown writes check results for a pull request comment.
+// Versions that need attention are listed first and all results are collapsed below them
+func writeCheckMarkdown(w io.Writer, r *checkReport) error {
+	headers := checkReportColumns(r.results)
+	var attention, all [][]string
+	counts := make(map[eoldate.Status]int)
+	for _, result := range r.results {
+		counts[result.Status]++
+		row := checkReportRow(result, len(headers))
+		all = append(all, row)
+		if result.Status != eoldate.StatusSupported {
+			attention = append(attention, row)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("### End of life check\n\n")
+	sb.WriteString(markdownSummary(counts))
+	if len(attention) > 0 {
+		if err := writeMarkdownTable(&sb, headers, markdownBadgeRows(headers, attention)); err != nil {
+			return err
+		}
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(&sb, "<details>\n<summary>All results (%d versions)</summary>\n\n", len(all))
+	if err := writeMarkdownTable(&sb, headers, markdownBadgeRows(headers, all)); err != nil {
+		return err
+	}
+	sb.WriteString("\n</details>\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// markdownSummary returns a line with the number of items per status
+func markdownSummary(counts map[eoldate.Status]int) string {
+	var parts []string
+	for _, status := range []eoldate.Status{eoldate.StatusEOL, eoldate.StatusApproachingEOL, eoldate.StatusSupported, eoldate.StatusUnknown} {
+		if counts[status] > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", markdownBadge(status), counts[status]))
+		}
+	}
+	if len(parts) == 0 {
+		return "No results.\n\n"
+	}
+	return strings.Join(parts, " &middot; ") + "\n\n"
+}
+
+// markdownBadgeRows returns a copy of rows with the status column shown as badges
+func markdownBadgeRows(headers []string, rows [][]string) [][]string {
+	statusColumn := -1
+	for i, header := range headers {
+		if header == eoldate.FieldStatus {
+			statusColumn = i
+		}
+	}
+	if statusColumn < 0 {
+		return rows
+	}
+	badged := make([][]string, len(rows))
+	for i, row := range rows {
+		badged[i] = append([]string{}, row...)
+		if badged[i][statusColumn] != "" {
+			badged[i][statusColumn] = markdownBadge(eoldate.Status(badged[i][statusColumn]))
+		}
+	}
+	return badged
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mr-pmillz/eoldate"
+)
+
+func TestWriteCheckMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeCheckMarkdown(&buf, &checkReport{results: checkResultsFixture()}); err != nil {
+		t.Fatalf("writeCheckMarkdown() error = %v", err)
+	}
+	attention, all, found := strings.Cut(buf.String(), "<details>")
+	if !found {
+		t.Fatalf("writeCheckMarkdown() = %s, want collapsed results", buf.String())
+	}
+	for _, want := range []string{
+		"### End of life check\n\n`EOL` 1 &middot; `APPROACHING EOL` 1 &middot; `SUPPORTED` 1 &middot; `UNKNOWN` 1\n",
+		"| php | 7.4.33 | `EOL` | 7.4 | 2022-11-28 | -765 | 7.4.33 | 8.3.12 |  | docker/Dockerfile:3 |",
+		"| nope | 1.0 | `UNKNOWN` |",
+	} {
+		if !strings.Contains(attention, want) {
+			t.Errorf("writeCheckMarkdown() attention section does not contain %q", want)
+		}
+	}
+	if strings.Contains(attention, "`SUPPORTED` | 8.3") {
+		t.Errorf("writeCheckMarkdown() lists supported versions as needing attention")
+	}
+	if !strings.Contains(all, "<summary>All results (4 versions)</summary>") || !strings.Contains(all, "| php | 8.3.12 | `SUPPORTED` |") {
+		t.Errorf("writeCheckMarkdown() collapsed section = %s", all)
+	}
+}
+
+func TestWriteProductsMarkdown(t *testing.T) {
+	var products eoldate.Products
+	if err := json.Unmarshal([]byte(phpFixture), &products); err != nil {
+		t.Fatal(err)
+	}
+	products = products.AnnotateWithin(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), eoldate.DefaultWarnDays)
+	tb := NewTableBuilder(products).SetNow(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err := tb.SetColumns([]string{"cycle", "eol", "status"}); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := writeProductsMarkdown(&buf, &productReport{name: "php", products: products, table: tb}); err != nil {
+		t.Fatalf("writeProductsMarkdown() error = %v", err)
+	}
+	current, history, _ := strings.Cut(buf.String(), "<details>")
+	if !strings.HasPrefix(current, "### php\n\n") || !strings.Contains(current, "| 8.1 | 2025-01-20 | `APPROACHING EOL` |") || strings.Contains(current, "| 7.4 |") {
+		t.Errorf("writeProductsMarkdown() current cycles = %s, want 8.3 and 8.1 only", current)
+	}
+	if !strings.Contains(history, "<summary>Full cycle history (3 cycles)</summary>") || !strings.Contains(history, "| 7.4 | 2022-11-28 | `EOL` |") {
+		t.Errorf("writeProductsMarkdown() history = %s", history)
+	}
+}
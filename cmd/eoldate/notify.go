@@ -0,0 +1,108 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mr-pmillz/eoldate"
+)
+
+// webhookSecretEnv is read for the webhook secret when -webhook-secret is not set, keeping it out of process listings
+const webhookSecretEnv = "EOLDATE_WEBHOOK_SECRET"
+
+// notifyOptions are the notifier flags of the check, scan and watch commands
+type notifyOptions struct {
+	webhook  string
+	secret   string
+	template string
+	format   string
+	retries  int
+	dryRun   bool
+}
+
+// webhookPayloads are the -webhook-format values
+var webhookPayloads = map[string]eoldate.PayloadFunc{
+	"json":  eoldate.JSONPayload,
+	"slack": eoldate.SlackPayload,
+	"teams": eoldate.TeamsPayload,
+}
+
+// register adds the notifier flags to a flag set
+func (n *notifyOptions) register(fs *flag.FlagSet) {
+	fs.StringVar(&n.webhook, "webhook", "", "POST events as JSON to this URL")
+	fs.StringVar(&n.secret, "webhook-secret", "", "sign webhook bodies with HMAC-SHA256 in the "+eoldate.WebhookSignatureHeader+" header (default $"+webhookSecretEnv+")")
+	fs.StringVar(&n.template, "webhook-template", "", "render the webhook body with this Go text/template file instead of the default JSON")
+	fs.StringVar(&n.format, "webhook-format", "json", "webhook body: json, slack (Block Kit) or teams (Adaptive Card)")
+	fs.IntVar(&n.retries, "webhook-retries", eoldate.DefaultWebhookRetries, "times to retry a webhook after network errors, 429 and 5xx responses")
+	fs.BoolVar(&n.dryRun, "dry-run", false, "print the webhook body to stdout instead of sending it, -webhook is not required")
+}
+
+// notifier returns the notifier selected by the flags, or nil when none is set
+func (n *notifyOptions) notifier() (eoldate.Notifier, error) {
+	if n.webhook == "" && !n.dryRun {
+		return nil, nil
+	}
+	payload, ok := webhookPayloads[n.format]
+	if !ok {
+		return nil, fmt.Errorf("invalid -webhook-format %q, must be json, slack or teams", n.format)
+	}
+	if n.template != "" && n.format != "json" {
+		return nil, fmt.Errorf("-webhook-template can not be combined with -webhook-format %s", n.format)
+	}
+	if n.template != "" {
+		data, err := os.ReadFile(n.template)
+		if err != nil {
+			return nil, err
+		}
+		if payload, err = eoldate.TemplatePayload(string(data)); err != nil {
+			return nil, err
+		}
+	}
+	if n.dryRun {
+		return &dryRunNotifier{w: os.Stdout, payload: payload}, nil
+	}
+	opts := eoldate.WebhookOptions{URL: n.webhook, Secret: n.secret, Retries: n.retries, Payload: payload}
+	if opts.Secret == "" {
+		opts.Secret = os.Getenv(webhookSecretEnv)
+	}
+	if opts.Retries == 0 {
+		opts.Retries = -1
+	}
+	notifier, err := eoldate.NewWebhookNotifier(opts)
+	if err != nil {
+		return nil, err
+	}
+	return notifier, nil
+}
+
+// dryRunNotifier writes the webhook body to w instead of sending it
+type dryRunNotifier struct {
+	w       io.Writer
+	payload eoldate.PayloadFunc
+}
+
+// Notify writes the body of events on its own line. Nothing is written without events, like the webhook sends nothing
+func (n *dryRunNotifier) Notify(events []eoldate.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	body, err := n.payload(events)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(n.w, "%s\n", body)
+	return err
+}
+
+// notifyCheckResults sends the EOL and approaching EOL results of a check to a notifier
+func notifyCheckResults(notifier eoldate.Notifier, results []eoldate.CheckResult, g *globalOptions) error {
+	if notifier == nil {
+		return nil
+	}
+	if err := notifier.Notify(eoldate.CheckEvents(results, g.now())); err != nil {
+		return fmt.Errorf("sending notification: %w", err)
+	}
+	return nil
+}
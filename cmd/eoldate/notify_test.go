@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mr-pmillz/eoldate"
+)
+
+func TestDryRunNotifier_Notify(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []eoldate.Event
+		want   string
+	}{
+		{name: "nil events", events: nil},
+		{name: "no events", events: []eoldate.Event{}},
+		{name: "events", events: []eoldate.Event{{Kind: eoldate.EventEOL, Product: "php", Cycle: "7.4"}}, want: `"cycle":"7.4"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			n := &dryRunNotifier{w: &buf, payload: eoldate.JSONPayload}
+			if err := n.Notify(tt.events); err != nil {
+				t.Fatalf("Notify() error = %v", err)
+			}
+			if tt.want == "" {
+				if buf.Len() != 0 {
+					t.Errorf("Notify() wrote %q, want nothing", buf.String())
+				}
+				return
+			}
+			if !strings.Contains(buf.String(), tt.want) || strings.Count(buf.String(), "\n") != 1 {
+				t.Errorf("Notify() wrote %q, want one line containing %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
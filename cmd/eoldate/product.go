@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/mr-pmillz/eoldate"
+	"github.com/projectdiscovery/gologger"
+)
+
+// runProduct prints the release cycles of a technology and optionally saves them to an output directory
+func runProduct(g *globalOptions, args []string) int {
+	fs := g.newFlagSet("product", "eoldate product [flags] <technology>")
+	tech := fs.String("t", "", "technology/software name to lookup")
+	output := fs.String("o", "", "output directory to save results to")
+	columns := fs.String("columns", "", "comma separated columns to show in order, e.g. cycle,latest,eol,support")
+	warnDays := fs.Int("warn-days", eoldate.DefaultWarnDays, "report and color dates within this many days as approaching EOL")
+	remindDays := fs.Int("remind-days", defaultRemindDays, "days before each event that -format ics sets a reminder, 0 for none")
+	var filters cycleFilters
+	filters.register(fs)
+	if err := g.parseFlags(fs, args); err != nil {
+		return flagExitCode(err, exitUsage)
+	}
+	predicates, err := filters.predicates(g.now())
+	if err != nil {
+		gologger.Error().Msg(err.Error())
+		return exitUsage
+	}
+	format := g.format
+	if format == "" {
+		format = "table"
+	}
+	if _, err = lookupProductFormat(format); err != nil && g.tmpl == nil {
+		gologger.Error().Msg(err.Error())
+		return exitUsage
+	}
+	if *tech == "" && fs.NArg() > 0 {
+		*tech = fs.Arg(0)
+	}
+
+	eolOptions := eoldate.Options{
+		Tech:   *tech,
+		Output: *output,
+	}
+
+	if eolOptions.Tech == "" {
+		gologger.Info().Msg("No technologies specified")
+		fs.Usage()
+		return exitUsage
+	}
+
+	if eolOptions.Output != "" {
+		var absOutputDir string
+		absOutputDir, err = eoldate.ResolveAbsPath(eolOptions.Output)
+		if err != nil {
+			gologger.Error().Msg(err.Error())
+			return exitError
+		}
+		eolOptions.Output = absOutputDir
+		if err = os.MkdirAll(absOutputDir, 0755); err != nil {
+			gologger.Error().Msg(err.Error())
+			return exitError
+		}
+	}
+
+	data, err := g.client().GetProduct(eolOptions.Tech)
+	if err != nil {
+		gologger.Error().Msgf("Error fetching product data: %v", err)
+		return exitError
+	}
+	data = data.Filter(predicates...).AnnotateWithin(g.now(), *warnDays)
+
+	selectedColumns := splitList(*columns)
+	report := &productReport{
+		name:       eolOptions.Tech,
+		products:   data,
+		table:      NewTableBuilder(data).SetColor(g.color()).SetNow(g.now()).SetWarnDays(*warnDays),
+		remindDays: *remindDays,
+	}
+	if err = report.table.SetColumns(selectedColumns); err != nil {
+		gologger.Error().Msg(err.Error())
+		return exitUsage
+	}
+	if len(selectedColumns) > 0 {
+		report.columns = report.table.Headers()
+	}
+	if g.tmpl != nil {
+		err = g.executeTemplate(os.Stdout, templateData{Name: eolOptions.Tech, WarnDays: *warnDays, Products: data})
+	} else {
+		err = writeProducts(os.Stdout, format, report)
+	}
+	if err != nil {
+		gologger.Error().Msg(err.Error())
+		return exitUsage
+	}
+
+	if eolOptions.Output != "" {
+		writeOutputFiles(eolOptions, format, report)
+	}
+	return exitOK
+}
+
+// defaultOutputFiles are the formats always written to the -o output directory
+var defaultOutputFiles = []string{"table", "json", "csv"}
+
+// writeOutputFiles saves the product report to the output directory in the default formats
+// and the format selected with -format, without colors
+func writeOutputFiles(options eoldate.Options, selected string, report *productReport) {
+	formats := defaultOutputFiles
+	if !slices.Contains(formats, selected) {
+		formats = append(append([]string{}, formats...), selected)
+	}
+	fileReport := *report
+	fileReport.table = NewTableBuilder(report.products).SetColor(false).SetNow(report.table.now).SetWarnDays(report.table.warnDays)
+	// the headers of the shown table are always available columns
+	_ = fileReport.table.SetColumns(report.table.Headers())
+
+	for _, name := range formats {
+		format, err := lookupFormat(name)
+		if err != nil || format.products == nil {
+			continue
+		}
+		filename := filepath.Join(options.Output, fmt.Sprintf("%s.%s", options.Tech, format.ext))
+		if err = writeReportFile(filename, func(w io.Writer) error { return format.products(w, &fileReport) }); err != nil {
+			gologger.Error().Msgf("Failed to write %s: %v", filename, err)
+		}
+	}
+}
+
+// writeReportFile creates or truncates filename and writes a report to it
+func writeReportFile(filename string, write func(w io.Writer) error) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err = write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunProduct_UnsupportedFormat(t *testing.T) {
+	for _, format := range []string{"bogus", "sarif", "junit"} {
+		t.Run(format, func(t *testing.T) {
+			cacheDir := t.TempDir()
+			outputDir := filepath.Join(t.TempDir(), "out")
+			var code int
+			out := captureStdout(t, func() {
+				code = run([]string{"product", "-cache-dir", cacheDir, "-format", format, "-o", outputDir, "php"})
+			})
+			if code != exitUsage || out != "" {
+				t.Errorf("product -format %s = %d, %q, want usage error without output", format, code, out)
+			}
+			// the format is rejected before the product is fetched and the output directory is created
+			if entries, _ := os.ReadDir(cacheDir); len(entries) != 0 {
+				t.Errorf("product -format %s wrote %d cache entries, want the product not fetched", format, len(entries))
+			}
+			if _, err := os.Stat(outputDir); !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("product -format %s created the output directory", format)
+			}
+		})
+	}
+}
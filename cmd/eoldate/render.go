@@ -0,0 +1,271 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mr-pmillz/eoldate"
+	"gopkg.in/yaml.v3"
+)
+
+// productReport is the data rendered by the product command
+type productReport struct {
+	name     string
+	products eoldate.Products
+	table    *TableBuilder
+	// columns are the columns selected with -columns, empty for the defaults
+	columns []string
+	// remindDays is how many days before each event -format ics sets a reminder
+	remindDays int
+}
+
+// checkReport is the data rendered by the check and scan commands
+type checkReport struct {
+	results []eoldate.CheckResult
+	color   bool
+	// now is the -as-of date or the current time the results were evaluated at
+	now time.Time
+	// warnDays is the -warn-days window the results were evaluated with
+	warnDays int
+	// junitApproaching is how -format junit reports versions approaching EOL
+	junitApproaching string
+	// remindDays is how many days before each event -format ics sets a reminder
+	remindDays int
+}
+
+// outputFormat renders product cycles and check results in one format.
+// A nil render function means the format does not support that kind of data
+type outputFormat struct {
+	ext      string
+	products func(w io.Writer, r *productReport) error
+	results  func(w io.Writer, r *checkReport) error
+}
+
+// outputFormats is the registry of all formats selectable with -format and written with -o
+var outputFormats = map[string]outputFormat{
+	"table": {
+		ext: "txt",
+		products: func(w io.Writer, r *productReport) error {
+			_, err := fmt.Fprintln(w, r.table.Render())
+			return err
+		},
+		results: func(w io.Writer, r *checkReport) error {
+			_, err := io.WriteString(w, renderCheckTable(checkReportColumns(r.results), r.results, r.color))
+			return err
+		},
+	},
+	"json": {
+		ext:      "json",
+		products: func(w io.Writer, r *productReport) error { return writeJSONIndent(w, r.products) },
+		results:  func(w io.Writer, r *checkReport) error { return writeJSONIndent(w, r.results) },
+	},
+	"jsonl": {
+		ext: "jsonl",
+		products: func(w io.Writer, r *productReport) error {
+			return writeJSONLines(w, len(r.products), func(i int) interface{} { return r.products[i] })
+		},
+		results: func(w io.Writer, r *checkReport) error {
+			return writeJSONLines(w, len(r.results), func(i int) interface{} { return r.results[i] })
+		},
+	},
+	"csv": {
+		ext:      "csv",
+		products: func(w io.Writer, r *productReport) error { return writeProductsDelimited(w, r, ',') },
+		results:  func(w io.Writer, r *checkReport) error { return writeCheckDelimited(w, r.results, ',') },
+	},
+	"tsv": {
+		ext:      "tsv",
+		products: func(w io.Writer, r *productReport) error { return writeProductsDelimited(w, r, '\t') },
+		results:  func(w io.Writer, r *checkReport) error { return writeCheckDelimited(w, r.results, '\t') },
+	},
+	"xlsx": {
+		ext:      "xlsx",
+		products: writeProductsXLSX,
+		results:  writeCheckXLSX,
+	},
+	"yaml": {
+		ext:      "yaml",
+		products: func(w io.Writer, r *productReport) error { return writeYAML(w, r.products) },
+		results:  func(w io.Writer, r *checkReport) error { return writeYAML(w, r.results) },
+	},
+	"svg": {
+		ext: "svg",
+		products: func(w io.Writer, r *productReport) error {
+			return eoldate.RenderLifecycleSVG(r.products, w, eoldate.SVGOptions{Title: r.name, At: r.table.now})
+		},
+	},
+	"timeline": {
+		ext:      "timeline.txt",
+		products: writeProductsTimeline,
+	},
+	"sarif": {
+		ext:     "sarif",
+		results: func(w io.Writer, r *checkReport) error { return writeSARIF(w, r.results) },
+	},
+	"ics": {
+		ext:      "ics",
+		products: writeProductsICS,
+		results:  writeCheckICS,
+	},
+	"html": {
+		ext:      "html",
+		products: writeProductsHTML,
+		results:  writeCheckHTML,
+	},
+	"junit": {
+		ext:     "xml",
+		results: func(w io.Writer, r *checkReport) error { return writeJUnit(w, r.results, r.junitApproaching) },
+	},
+	"markdown": {
+		ext:      "md",
+		products: writeProductsMarkdown,
+		results:  writeCheckMarkdown,
+	},
+}
+
+// formatNames returns the names of all registered output formats
+func formatNames() []string {
+	names := make([]string, 0, len(outputFormats))
+	for name := range outputFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupFormat returns the registered output format with the given name
+func lookupFormat(name string) (outputFormat, error) {
+	format, ok := outputFormats[name]
+	if !ok {
+		return outputFormat{}, fmt.Errorf("unsupported format %q, choose one of: %s", name, strings.Join(formatNames(), ", "))
+	}
+	return format, nil
+}
+
+// lookupProductFormat returns the registered output format with the given name that renders product cycles
+func lookupProductFormat(name string) (outputFormat, error) {
+	format, err := lookupFormat(name)
+	if err != nil {
+		return outputFormat{}, err
+	}
+	if format.products == nil {
+		return outputFormat{}, fmt.Errorf("format %q does not support product output", name)
+	}
+	return format, nil
+}
+
+// writeProducts renders product cycles in the named format
+func writeProducts(w io.Writer, name string, r *productReport) error {
+	format, err := lookupProductFormat(name)
+	if err != nil {
+		return err
+	}
+	return format.products(w, r)
+}
+
+// writeCheckReport renders check results in the named format
+func writeCheckReport(w io.Writer, name string, r *checkReport) error {
+	format, err := lookupFormat(name)
+	if err != nil {
+		return err
+	}
+	if format.results == nil {
+		return fmt.Errorf("format %q does not support check results", name)
+	}
+	return format.results(w, r)
+}
+
+// writeJSONIndent writes v as indented JSON
+func writeJSONIndent(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// writeJSONLines writes n items as one JSON document per line
+func writeJSONLines(w io.Writer, n int, item func(i int) interface{}) error {
+	enc := json.NewEncoder(w)
+	for i := 0; i < n; i++ {
+		if err := enc.Encode(item(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeYAML writes v as YAML, keeping the field names and order of its JSON encoding
+func writeYAML(w io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var node yaml.Node
+	if err = yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	resetYAMLStyle(&node)
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err = enc.Encode(&node); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// resetYAMLStyle switches a node tree decoded from JSON from flow style to block style
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
+
+// writeProductsDelimited writes product cycles as CSV with the given field delimiter,
+// limited to the columns selected with -columns
+func writeProductsDelimited(w io.Writer, r *productReport, comma rune) error {
+	return eoldate.WriteProductsCSV(w, r.products, eoldate.CSVOptions{Comma: comma, Columns: r.columns})
+}
+
+// writeCheckDelimited writes check results as CSV with the given field delimiter
+func writeCheckDelimited(w io.Writer, results []eoldate.CheckResult, comma rune) error {
+	headers := checkReportColumns(results)
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	if err := cw.Write(headers); err != nil {
+		return err
+	}
+	for _, result := range results {
+		if err := cw.Write(checkReportRow(result, len(headers))); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeMarkdownTable writes a GitHub flavored markdown table
+func writeMarkdownTable(w io.Writer, headers []string, rows [][]string) error {
+	var sb strings.Builder
+	sb.WriteString("| " + strings.Join(escapeMarkdownCells(headers), " | ") + " |\n")
+	sb.WriteString("|" + strings.Repeat(" --- |", len(headers)) + "\n")
+	for _, row := range rows {
+		sb.WriteString("| " + strings.Join(escapeMarkdownCells(row), " | ") + " |\n")
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// escapeMarkdownCells escapes pipes and newlines so cell values don't break the table
+func escapeMarkdownCells(cells []string) []string {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		cell = strings.ReplaceAll(cell, "|", `\|`)
+		escaped[i] = strings.ReplaceAll(cell, "\n", "<br>")
+	}
+	return escaped
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mr-pmillz/eoldate"
+	"github.com/olekukonko/tablewriter"
+)
+
+// checkReportHeaders are the columns of a batch check report
+var checkReportHeaders = []string{"product", "version", "status", "cycle", "eol", "days_to_eol", "latest", "recommended_upgrade", "error"}
+
+// checkReportColumns returns the report columns, adding a source column when results were found by a scanner
+func checkReportColumns(results []eoldate.CheckResult) []string {
+	for _, result := range results {
+		if result.Source != "" {
+			return append(append([]string{}, checkReportHeaders...), "source")
+		}
+	}
+	return checkReportHeaders
+}
+
+// checkReportRow flattens a check result into the first n report columns
+func checkReportRow(result eoldate.CheckResult, n int) []string {
+	days := ""
+	if result.DaysUntilEOL != nil {
+		days = strconv.Itoa(*result.DaysUntilEOL)
+	}
+	source := result.Source
+	if source != "" && result.Line > 0 {
+		source = fmt.Sprintf("%s:%d", source, result.Line)
+	}
+	row := []string{
+		result.Product,
+		result.Version,
+		string(result.Status),
+		result.Cycle,
+		result.EOLDate,
+		days,
+		result.Latest,
+		result.RecommendedUpgrade,
+		result.Error,
+		source,
+	}
+	return row[:n]
+}
+
+// renderCheckTable renders check results as a table, coloring the status column
+func renderCheckTable(headers []string, results []eoldate.CheckResult, color bool) string {
+	var buf strings.Builder
+	table := tablewriter.NewWriter(&buf)
+	table.SetHeader(headers)
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(true)
+	table.SetHeaderAlignment(tablewriter.ALIGN_CENTER)
+	table.SetAlignment(tablewriter.ALIGN_CENTER)
+
+	if color {
+		headerColors := make([]tablewriter.Colors, len(headers))
+		for i := range headerColors {
+			headerColors[i] = tablewriter.Colors{tablewriter.Bold, tablewriter.FgHiYellowColor, tablewriter.BgBlackColor}
+		}
+		table.SetHeaderColor(headerColors...)
+	}
+
+	for _, result := range results {
+		row := checkReportRow(result, len(headers))
+		if !color {
+			table.Append(row)
+			continue
+		}
+		colors := make([]tablewriter.Colors, len(headers))
+		colors[2] = statusColor(result.Status)
+		table.Rich(row, colors)
+	}
+
+	table.Render()
+	return buf.String()
+}
+
+// checkResultMessage describes a check result that is not supported in one sentence
+func checkResultMessage(result eoldate.CheckResult) string {
+	name := fmt.Sprintf("%s %s", result.Product, result.Version)
+	var msg string
+	switch {
+	case result.Status == eoldate.StatusEOL && result.EOLDate != "":
+		msg = fmt.Sprintf("%s (cycle %s) reached end of life on %s.", name, result.Cycle, result.EOLDate)
+	case result.Status == eoldate.StatusEOL:
+		msg = fmt.Sprintf("%s (cycle %s) has reached end of life.", name, result.Cycle)
+	case result.Status == eoldate.StatusApproachingEOL:
+		msg = fmt.Sprintf("%s (cycle %s) reaches end of life on %s.", name, result.Cycle, result.EOLDate)
+	default:
+		msg = fmt.Sprintf("%s: %s.", name, result.Error)
+	}
+	if result.RecommendedUpgrade != "" {
+		msg += fmt.Sprintf(" Upgrade to %s.", result.RecommendedUpgrade)
+	}
+	return msg
+}
+
+// statusColor returns the color used to display a check status
+func statusColor(status eoldate.Status) tablewriter.Colors {
+	switch status {
+	case eoldate.StatusSupported:
+		return tablewriter.Colors{tablewriter.FgGreenColor}
+	case eoldate.StatusApproachingEOL:
+		return tablewriter.Colors{tablewriter.FgYellowColor}
+	case eoldate.StatusEOL:
+		return tablewriter.Colors{tablewriter.FgRedColor}
+	default:
+		return tablewriter.Colors{tablewriter.FgMagentaColor}
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mr-pmillz/eoldate"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	sarifSrcRoot = "%SRCROOT%"
+)
+
+// sarifLog is the root object of a SARIF 2.1.0 file
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	FullDescription      sarifMessage       `json:"fullDescription"`
+	HelpURI              string             `json:"helpUri"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+	Properties           sarifRuleProps     `json:"properties"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifRuleProps struct {
+	Tags             []string `json:"tags"`
+	SecuritySeverity string   `json:"security-severity"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string                 `json:"ruleId"`
+	RuleIndex  int                    `json:"ruleIndex"`
+	Level      string                 `json:"level"`
+	Message    sarifMessage           `json:"message"`
+	Locations  []sarifLocation        `json:"locations,omitempty"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// sarifRules are the rules reported by eoldate, one per severity.
+// Supported versions are not reported
+var sarifRules = []struct {
+	status eoldate.Status
+	rule   sarifRule
+}{
+	{status: eoldate.StatusEOL, rule: sarifRule{
+		ID:                   "eoldate/eol",
+		Name:                 "EndOfLife",
+		ShortDescription:     sarifMessage{Text: "Version has reached end of life"},
+		FullDescription:      sarifMessage{Text: "The release cycle of this version no longer receives security fixes. Upgrade to a supported release."},
+		HelpURI:              "https://endoflife.date",
+		DefaultConfiguration: sarifConfiguration{Level: "error"},
+		Properties:           sarifRuleProps{Tags: []string{"security", "end-of-life"}, SecuritySeverity: "7.5"},
+	}},
+	{status: eoldate.StatusApproachingEOL, rule: sarifRule{
+		ID:                   "eoldate/approaching-eol",
+		Name:                 "ApproachingEndOfLife",
+		ShortDescription:     sarifMessage{Text: "Version is approaching end of life"},
+		FullDescription:      sarifMessage{Text: "The release cycle of this version reaches end of life soon. Plan an upgrade to a supported release."},
+		HelpURI:              "https://endoflife.date",
+		DefaultConfiguration: sarifConfiguration{Level: "warning"},
+		Properties:           sarifRuleProps{Tags: []string{"security", "end-of-life"}, SecuritySeverity: "4.0"},
+	}},
+	{status: eoldate.StatusUnknown, rule: sarifRule{
+		ID:                   "eoldate/unknown",
+		Name:                 "UnknownSupportStatus",
+		ShortDescription:     sarifMessage{Text: "Support status could not be determined"},
+		FullDescription:      sarifMessage{Text: "The product or version could not be matched to a release cycle on endoflife.date."},
+		HelpURI:              "https://endoflife.date",
+		DefaultConfiguration: sarifConfiguration{Level: "note"},
+		Properties:           sarifRuleProps{Tags: []string{"end-of-life"}, SecuritySeverity: "0.0"},
+	}},
+}
+
+// writeSARIF writes check results as a SARIF 2.1.0 log with one result per version that is not supported
+func writeSARIF(w io.Writer, results []eoldate.CheckResult) error {
+	driver := sarifDriver{
+		Name:           "eoldate",
+		Version:        strings.TrimPrefix(eoldate.CurrentVersion, "v"),
+		InformationURI: "https://github.com/mr-pmillz/eoldate",
+	}
+	ruleIndex := make(map[eoldate.Status]int, len(sarifRules))
+	for i, r := range sarifRules {
+		driver.Rules = append(driver.Rules, r.rule)
+		ruleIndex[r.status] = i
+	}
+
+	run := sarifRun{Tool: sarifTool{Driver: driver}, Results: []sarifResult{}}
+	for _, result := range results {
+		index, ok := ruleIndex[result.Status]
+		if !ok {
+			continue
+		}
+		rule := driver.Rules[index]
+		sr := sarifResult{
+			RuleID:     rule.ID,
+			RuleIndex:  index,
+			Level:      rule.DefaultConfiguration.Level,
+			Message:    sarifMessage{Text: checkResultMessage(result)},
+			Properties: sarifProperties(result),
+		}
+		if result.Source != "" {
+			location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifact(result.Source)}}
+			if result.Line > 0 {
+				location.PhysicalLocation.Region = &sarifRegion{StartLine: result.Line}
+			}
+			sr.Locations = []sarifLocation{location}
+		}
+		run.Results = append(run.Results, sr)
+	}
+
+	return writeJSONIndent(w, sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}})
+}
+
+// sarifProperties returns the check result fields attached to a SARIF result
+func sarifProperties(result eoldate.CheckResult) map[string]interface{} {
+	props := map[string]interface{}{
+		"product": result.Product,
+		"version": result.Version,
+		"status":  result.Status,
+	}
+	for key, value := range map[string]string{
+		"cycle":              result.Cycle,
+		"eolDate":            result.EOLDate,
+		"recommendedUpgrade": result.RecommendedUpgrade,
+		"link":               result.Link,
+	} {
+		if value != "" {
+			props[key] = value
+		}
+	}
+	if result.DaysUntilEOL != nil {
+		props["daysUntilEOL"] = *result.DaysUntilEOL
+	}
+	return props
+}
+
+// sarifArtifact returns the location of a scanned file, relative to the source root when possible
+func sarifArtifact(source string) sarifArtifactLocation {
+	if filepath.IsAbs(source) {
+		wd, err := os.Getwd()
+		rel := ""
+		if err == nil {
+			rel, err = filepath.Rel(wd, source)
+		}
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return sarifArtifactLocation{URI: (&url.URL{Scheme: "file", Path: filepath.ToSlash(source)}).String()}
+		}
+		source = rel
+	}
+	return sarifArtifactLocation{URI: filepath.ToSlash(filepath.Clean(source)), URIBaseID: sarifSrcRoot}
+}
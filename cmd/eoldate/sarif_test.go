@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestWriteSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeSARIF(&buf, checkResultsFixture()); err != nil {
+		t.Fatalf("writeSARIF() error = %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("writeSARIF() = %s, not JSON: %v", buf.String(), err)
+	}
+	if log.Version != "2.1.0" || log.Schema != sarifSchema || len(log.Runs) != 1 {
+		t.Fatalf("writeSARIF() = version %q, schema %q, %d runs, want one SARIF 2.1.0 run", log.Version, log.Schema, len(log.Runs))
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != "eoldate" {
+		t.Errorf("driver = %q, want eoldate", run.Tool.Driver.Name)
+	}
+	wantRules := []string{"eoldate/eol", "eoldate/approaching-eol", "eoldate/unknown"}
+	if len(run.Tool.Driver.Rules) != len(wantRules) {
+		t.Fatalf("rules = %+v, want %v", run.Tool.Driver.Rules, wantRules)
+	}
+	for i, id := range wantRules {
+		if run.Tool.Driver.Rules[i].ID != id {
+			t.Errorf("rules[%d] = %s, want %s", i, run.Tool.Driver.Rules[i].ID, id)
+		}
+	}
+
+	// the supported version is not reported
+	tests := []struct {
+		ruleID    string
+		ruleIndex int
+		level     string
+		message   string
+		uri       string
+		line      int
+	}{
+		{ruleID: "eoldate/eol", ruleIndex: 0, level: "error", message: "php 7.4.33 (cycle 7.4) reached end of life on 2022-11-28. Upgrade to 8.3.12.", uri: "docker/Dockerfile", line: 3},
+		{ruleID: "eoldate/approaching-eol", ruleIndex: 1, level: "warning", message: "php 8.1.30 (cycle 8.1) reaches end of life on 2025-01-20. Upgrade to 8.3.12.", uri: "composer.json"},
+		{ruleID: "eoldate/unknown", ruleIndex: 2, level: "note", message: "nope 1.0: product not found: nope.", uri: ".tool-versions", line: 2},
+	}
+	if len(run.Results) != len(tests) {
+		t.Fatalf("results = %+v, want %d", run.Results, len(tests))
+	}
+	for i, tt := range tests {
+		result := run.Results[i]
+		if result.RuleID != tt.ruleID || result.RuleIndex != tt.ruleIndex || result.Level != tt.level || result.Message.Text != tt.message {
+			t.Errorf("results[%d] = %s[%d] %s %q, want %s[%d] %s %q", i, result.RuleID, result.RuleIndex, result.Level, result.Message.Text, tt.ruleID, tt.ruleIndex, tt.level, tt.message)
+		}
+		if len(result.Locations) != 1 {
+			t.Fatalf("results[%d] locations = %+v, want one", i, result.Locations)
+		}
+		location := result.Locations[0].PhysicalLocation
+		if location.ArtifactLocation.URI != tt.uri || location.ArtifactLocation.URIBaseID != sarifSrcRoot {
+			t.Errorf("results[%d] artifact = %+v, want %s relative to %s", i, location.ArtifactLocation, tt.uri, sarifSrcRoot)
+		}
+		switch {
+		case tt.line == 0 && location.Region != nil:
+			t.Errorf("results[%d] region = %+v, want none without a line", i, location.Region)
+		case tt.line != 0 && (location.Region == nil || location.Region.StartLine != tt.line):
+			t.Errorf("results[%d] region = %+v, want start line %d", i, location.Region, tt.line)
+		}
+	}
+	if run.Results[0].Properties["recommendedUpgrade"] != "8.3.12" || run.Results[0].Properties["daysUntilEOL"] != float64(-765) {
+		t.Errorf("results[0] properties = %v", run.Results[0].Properties)
+	}
+}
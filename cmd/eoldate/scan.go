@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mr-pmillz/eoldate"
+)
+
+// runScan detects versions used in directories and checks them against their end of life dates
+func runScan(g *globalOptions, args []string) int {
+	fs := g.newFlagSet("scan", "eoldate scan [flags] [path ...]")
+	warnDays := fs.Int("warn-days", eoldate.DefaultWarnDays, "report versions reaching EOL within this many days as approaching EOL")
+	concurrency := fs.Int("concurrency", 8, "number of products to fetch in parallel")
+	junitApproaching := fs.String("junit-approaching", junitSkipped, "how -format junit reports versions approaching EOL: skipped, failure or passed")
+	remindDays := fs.Int("remind-days", defaultRemindDays, "days before each event that -format ics sets a reminder, 0 for none")
+	var notify notifyOptions
+	notify.register(fs)
+	usage := fs.Usage
+	fs.Usage = func() {
+		usage()
+		fmt.Fprintf(fs.Output(), "\nDetects versions in Dockerfiles, go.mod, package.json, composer.json, .tool-versions,\n.nvmrc, .node-version, .python-version, .ruby-version, .go-version and .terraform-version files.\n")
+		printCheckExitCodes(fs)
+	}
+	if err := g.parseFlags(fs, args); err != nil {
+		return flagExitCode(err, exitLookupError)
+	}
+	notifier, err := notify.notifier()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+		return exitLookupError
+	}
+
+	paths := fs.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	var items []eoldate.InventoryItem
+	for _, path := range paths {
+		found, err := eoldate.ScanDir(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: scanning %s: %v\n", path, err)
+			return exitLookupError
+		}
+		items = append(items, found...)
+	}
+	if len(items) == 0 {
+		fmt.Fprintln(os.Stderr, "no versions detected")
+		return exitSupported
+	}
+
+	opts := eoldate.CheckOptions{WarnDays: *warnDays, Concurrency: *concurrency, At: g.asOfTime}
+	results := g.client().CheckInventory(items, opts)
+	format := g.format
+	if format == "" {
+		format = "table"
+	}
+	switch {
+	case notify.dryRun:
+		// the notification body replaces the report on stdout
+	case g.tmpl != nil:
+		err = g.executeTemplate(os.Stdout, templateData{WarnDays: *warnDays, Results: results})
+	default:
+		err = writeCheckReport(os.Stdout, format, &checkReport{results: results, color: g.color(), now: g.now(), warnDays: *warnDays, junitApproaching: *junitApproaching, remindDays: *remindDays})
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+		return exitLookupError
+	}
+	if err = notifyCheckResults(notifier, results, g); err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+		return exitLookupError
+	}
+	return checkExitCode(results)
+}
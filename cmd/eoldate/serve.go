@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mr-pmillz/eoldate"
+	"github.com/projectdiscovery/gologger"
+)
+
+// server serves product lookups and version checks over HTTP
+type server struct {
+	client   *eoldate.Client
+	warnDays int
+	at       time.Time
+}
+
+// runServe starts an HTTP server exposing product lookups and version checks as JSON
+func runServe(g *globalOptions, args []string) int {
+	fs := g.newFlagSet("serve", "eoldate serve [flags]\n\nEndpoints:\n  GET  /api/products             all technologies\n  GET  /api/products/{product}   release cycles of a technology\n  GET  /api/check?product=&version=\n  POST /api/check                JSON array of {\"product\": \"\", \"version\": \"\"}")
+	addr := fs.String("addr", "127.0.0.1:8080", "address to listen on")
+	warnDays := fs.Int("warn-days", eoldate.DefaultWarnDays, "report versions reaching EOL within this many days as approaching EOL")
+	if err := g.parseFlags(fs, args); err != nil {
+		return flagExitCode(err, exitUsage)
+	}
+
+	s := &server{client: g.client(), warnDays: *warnDays, at: g.asOfTime}
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           s.routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	gologger.Info().Msgf("Listening on http://%s", *addr)
+	if err := httpServer.ListenAndServe(); err != nil {
+		gologger.Error().Msg(err.Error())
+		return exitError
+	}
+	return exitOK
+}
+
+// routes returns the handler of all API endpoints
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/products", s.handleAllProducts)
+	mux.HandleFunc("GET /api/products/{product}", s.handleProduct)
+	mux.HandleFunc("GET /api/check", s.handleCheck)
+	mux.HandleFunc("POST /api/check", s.handleCheckInventory)
+	return mux
+}
+
+func (s *server) handleAllProducts(w http.ResponseWriter, _ *http.Request) {
+	allProducts, err := s.client.GetAllProducts()
+	if err != nil {
+		writeJSONError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, allProducts)
+}
+
+func (s *server) handleProduct(w http.ResponseWriter, r *http.Request) {
+	products, err := s.client.GetProduct(r.PathValue("product"))
+	if err != nil {
+		writeJSONError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, products)
+}
+
+func (s *server) handleCheck(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("product") == "" || query.Get("version") == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "product and version are required"})
+		return
+	}
+	result := s.client.CheckVersion(query.Get("product"), query.Get("version"), s.checkOptions(r))
+	if result.Err != nil {
+		writeJSONError(w, result.Err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *server) handleCheckInventory(w http.ResponseWriter, r *http.Request) {
+	var items []eoldate.InventoryItem
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&items); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, s.client.CheckInventory(items, s.checkOptions(r)))
+}
+
+// checkOptions returns the check options of a request, allowing warn-days to be overridden
+func (s *server) checkOptions(r *http.Request) eoldate.CheckOptions {
+	opts := eoldate.CheckOptions{WarnDays: s.warnDays, Concurrency: 8, At: s.at}
+	if warnDays, err := strconv.Atoi(r.URL.Query().Get("warn-days")); err == nil {
+		opts.WarnDays = warnDays
+	}
+	return opts
+}
+
+// writeJSONError writes an error response with a status code derived from the error type
+func writeJSONError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var httpErr *eoldate.HTTPError
+	switch {
+	case errors.Is(err, eoldate.ErrProductNotFound), errors.Is(err, eoldate.ErrNoCycleMatch):
+		status = http.StatusNotFound
+	case errors.Is(err, eoldate.ErrInvalidVersion):
+		status = http.StatusBadRequest
+	case errors.As(err, &httpErr):
+		status = http.StatusBadGateway
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// writeJSON writes v as a JSON response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		gologger.Error().Msgf("Failed to write response: %v", err)
+	}
+}
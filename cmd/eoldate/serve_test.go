@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mr-pmillz/eoldate"
+)
+
+// newTestServer returns the API handler backed by a fake endoflife.date API,
+// where unavailable is listed but fails with a 503
+func newTestServer(t *testing.T) http.Handler {
+	t.Helper()
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/all.json":
+			_, _ = w.Write([]byte(`["php","unavailable"]`))
+		case "/php.json":
+			_, _ = w.Write([]byte(phpFixture))
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(api.Close)
+	client := eoldate.NewClient(eoldate.WithBaseURL(api.URL), eoldate.WithCacheDir(t.TempDir()))
+	s := &server{client: client, warnDays: eoldate.DefaultWarnDays, at: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	return s.routes()
+}
+
+func TestServer_Routes(t *testing.T) {
+	handler := newTestServer(t)
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		wantStatus int
+		want       string
+	}{
+		{name: "all products", method: http.MethodGet, target: "/api/products", wantStatus: http.StatusOK, want: `["php","unavailable"]`},
+		{name: "product", method: http.MethodGet, target: "/api/products/php", wantStatus: http.StatusOK, want: `"latest":"8.3.12"`},
+		{name: "unknown product", method: http.MethodGet, target: "/api/products/nope", wantStatus: http.StatusNotFound, want: `"error":"product not found: nope"`},
+		{name: "upstream failure", method: http.MethodGet, target: "/api/products/unavailable", wantStatus: http.StatusBadGateway, want: `503`},
+		{name: "check", method: http.MethodGet, target: "/api/check?product=php&version=8.1.30", wantStatus: http.StatusOK, want: `"status":"approaching-eol"`},
+		{name: "check with warn-days", method: http.MethodGet, target: "/api/check?product=php&version=8.1.30&warn-days=7", wantStatus: http.StatusOK, want: `"status":"supported"`},
+		{name: "invalid warn-days is ignored", method: http.MethodGet, target: "/api/check?product=php&version=8.1.30&warn-days=soon", wantStatus: http.StatusOK, want: `"status":"approaching-eol"`},
+		{name: "check without version", method: http.MethodGet, target: "/api/check?product=php", wantStatus: http.StatusBadRequest, want: `"error":"product and version are required"`},
+		{name: "check invalid version", method: http.MethodGet, target: "/api/check?product=php&version=not-a-version", wantStatus: http.StatusBadRequest, want: `invalid version string`},
+		{name: "check unknown cycle", method: http.MethodGet, target: "/api/check?product=php&version=9.0", wantStatus: http.StatusNotFound},
+		{name: "check inventory", method: http.MethodPost, target: "/api/check", body: `[{"product":"php","version":"8.3.12"},{"product":"php","version":"7.4.33"}]`, wantStatus: http.StatusOK, want: `"status":"eol"`},
+		{name: "check invalid inventory", method: http.MethodPost, target: "/api/check", body: `{"product":"php"}`, wantStatus: http.StatusBadRequest},
+		{name: "method not allowed", method: http.MethodDelete, target: "/api/check", wantStatus: http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body)))
+			if rec.Code != tt.wantStatus {
+				t.Errorf("%s %s status = %d, want %d: %s", tt.method, tt.target, rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantStatus != http.StatusMethodNotAllowed && rec.Header().Get("Content-Type") != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", rec.Header().Get("Content-Type"))
+			}
+			if !strings.Contains(rec.Body.String(), tt.want) {
+				t.Errorf("%s %s body = %s, want %s", tt.method, tt.target, rec.Body, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteJSONError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{err: fmt.Errorf("%w: nope", eoldate.ErrProductNotFound), want: http.StatusNotFound},
+		{err: fmt.Errorf("%w: 9.0", eoldate.ErrNoCycleMatch), want: http.StatusNotFound},
+		{err: fmt.Errorf("%w: x", eoldate.ErrInvalidVersion), want: http.StatusBadRequest},
+		{err: fmt.Errorf("fetching: %w", &eoldate.HTTPError{URL: "https://endoflife.date/api/php.json", StatusCode: 503, Status: "503 Service Unavailable"}), want: http.StatusBadGateway},
+		{err: errors.New("disk full"), want: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			writeJSONError(rec, tt.err)
+			if rec.Code != tt.want {
+				t.Errorf("writeJSONError(%v) status = %d, want %d", tt.err, rec.Code, tt.want)
+			}
+			var body map[string]string
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body["error"] != tt.err.Error() {
+				t.Errorf("writeJSONError(%v) body = %s, want the error message", tt.err, rec.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mr-pmillz/eoldate"
+	"github.com/olekukonko/tablewriter"
+)
+
+// defaultColumnOrder is the order of the well known columns, other columns follow alphabetically and link comes last
+var defaultColumnOrder = []string{
+	"cycle",
+	"releaseDate",
+	"latest",
+	"latestReleaseDate",
+	"lts",
+	"support",
+	"eol",
+	"extendedSupport",
+	eoldate.FieldStatus,
+	eoldate.FieldEOLIn,
+	eoldate.FieldDaysToEOL,
+	eoldate.FieldDaysToSupportEnd,
+}
+
+// TableBuilder handles the creation and population of the table
+type TableBuilder struct {
+	products []eoldate.Product
+	headers  []string
+	rows     [][]string
+	color    bool
+	now      time.Time
+	warnDays int
+}
+
+// NewTableBuilder creates a new TableBuilder instance
+func NewTableBuilder(products []eoldate.Product) *TableBuilder {
+	tb := &TableBuilder{products: products, color: true, now: time.Now(), warnDays: eoldate.DefaultWarnDays}
+	tb.determineHeaders()
+	tb.buildRows()
+	return tb
+}
+
+// SetColor enables or disables ANSI colors in the rendered table
+func (tb *TableBuilder) SetColor(color bool) *TableBuilder {
+	tb.color = color
+	return tb
+}
+
+// SetNow sets the date that EOL and support dates are colored relative to
+func (tb *TableBuilder) SetNow(now time.Time) *TableBuilder {
+	tb.now = now
+	return tb
+}
+
+// SetWarnDays sets the number of days before a date that it is colored as approaching
+func (tb *TableBuilder) SetWarnDays(days int) *TableBuilder {
+	tb.warnDays = days
+	return tb
+}
+
+// SetColumns selects the columns of the table and their order.
+// Names are matched ignoring case, dashes and underscores against the product fields and AdditionalFields,
+// so release_date selects releaseDate. An empty list restores the default columns.
+// Unknown names are rejected with an error listing the available columns
+func (tb *TableBuilder) SetColumns(columns []string) error {
+	if len(columns) == 0 {
+		tb.determineHeaders()
+	} else {
+		available := make(map[string]string)
+		names := tb.availableColumns()
+		for _, header := range names {
+			available[normalizeColumn(header)] = header
+		}
+		headers := make([]string, 0, len(columns))
+		for _, column := range columns {
+			header, ok := available[normalizeColumn(column)]
+			if !ok {
+				return fmt.Errorf("unknown column %q, available columns: %s", column, strings.Join(names, ", "))
+			}
+			headers = append(headers, header)
+		}
+		tb.headers = headers
+	}
+	tb.rows = nil
+	tb.buildRows()
+	return nil
+}
+
+// Headers returns the columns of the table in order
+func (tb *TableBuilder) Headers() []string {
+	return tb.headers
+}
+
+// availableColumns returns the names of all product fields and the AdditionalFields keys of all products
+func (tb *TableBuilder) availableColumns() []string {
+	return eoldate.ProductCSVColumns(tb.products)
+}
+
+// determineHeaders identifies all unique non-empty keys across all products, including AdditionalFields
+func (tb *TableBuilder) determineHeaders() {
+	headerSet := make(map[string]bool)
+	columns := eoldate.ProductCSVColumns(tb.products)
+	for _, product := range tb.products {
+		for _, column := range columns {
+			if !isEmptyValue(product.Field(column)) {
+				headerSet[column] = true
+			}
+		}
+	}
+
+	tb.headers = make([]string, 0, len(headerSet))
+	for _, header := range defaultColumnOrder {
+		if headerSet[header] {
+			tb.headers = append(tb.headers, header)
+			delete(headerSet, header)
+		}
+	}
+	rest := make([]string, 0, len(headerSet))
+	for header := range headerSet {
+		if header != "" && header != "link" {
+			rest = append(rest, header)
+		}
+	}
+	sort.Strings(rest)
+	tb.headers = append(tb.headers, rest...)
+	if headerSet["link"] {
+		tb.headers = append(tb.headers, "link")
+	}
+}
+
+// normalizeColumn lowercases a column name and strips dashes, underscores and spaces
+func normalizeColumn(name string) string {
+	return strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.ToLower(name))
+}
+
+// isEmptyValue checks if a value is considered empty
+func isEmptyValue(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	switch value := v.(type) {
+	case string:
+		return value == ""
+	case bool:
+		return !value
+	case int, int8, int16, int32, int64:
+		return value == 0
+	case float32, float64:
+		return value == 0
+	case []interface{}:
+		return len(value) == 0
+	case map[string]interface{}:
+		return len(value) == 0
+	case *float64:
+		return value == nil
+	default:
+		return false
+	}
+}
+
+// buildRows constructs the rows for the table
+func (tb *TableBuilder) buildRows() {
+	fields := make(map[string]bool)
+	for _, column := range eoldate.ProductCSVColumns(nil) {
+		fields[column] = true
+	}
+	for _, product := range tb.products {
+		row := make([]string, len(tb.headers))
+		for i, header := range tb.headers {
+			// product fields without a value are not available, additional fields a cycle does not have are empty
+			if _, ok := product.AdditionalFields[header]; ok || fields[header] {
+				row[i] = tb.formatValue(product.Field(header))
+			}
+		}
+		tb.rows = append(tb.rows, row)
+	}
+}
+
+// formatValue converts an interface{} value to a string representation
+func (tb *TableBuilder) formatValue(v interface{}) string {
+	if v == nil {
+		return eoldate.NotAvailable
+	}
+
+	switch value := v.(type) {
+	case string:
+		return value
+	case int:
+		return strconv.Itoa(value)
+	case float64:
+		if value == float64(int64(value)) {
+			return fmt.Sprintf("%.0f", value)
+		}
+		return fmt.Sprintf("%.2f", value)
+	case *float64:
+		if value == nil {
+			return eoldate.NotAvailable
+		}
+		if *value == float64(int64(*value)) {
+			return fmt.Sprintf("%.0f", *value)
+		}
+		return fmt.Sprintf("%.2f", *value)
+	case bool:
+		return fmt.Sprintf("%t", value)
+	case time.Time:
+		return value.Format("2006-01-02")
+	case interface{}:
+		return fmt.Sprintf("%v", value)
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// Render creates and renders the table
+func (tb *TableBuilder) Render() string {
+	var buf strings.Builder
+	table := tablewriter.NewWriter(&buf)
+	table.SetHeader(tb.headers)
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(true)
+	table.SetHeaderAlignment(tablewriter.ALIGN_CENTER)
+	table.SetAlignment(tablewriter.ALIGN_CENTER)
+
+	if !tb.color {
+		table.AppendBulk(tb.rows)
+		table.Render()
+		return buf.String()
+	}
+
+	// Set header colors
+	headerColors := make([]tablewriter.Colors, len(tb.headers))
+	for i := range headerColors {
+		headerColors[i] = tablewriter.Colors{tablewriter.Bold, tablewriter.FgHiYellowColor, tablewriter.BgBlackColor}
+	}
+	table.SetHeaderColor(headerColors...)
+
+	for _, row := range tb.rows {
+		colors := tb.colorizeRow(row)
+		table.Rich(row, colors)
+	}
+
+	table.Render()
+	return buf.String()
+}
+
+// colorizeRow applies color to specific columns based on their values
+func (tb *TableBuilder) colorizeRow(row []string) []tablewriter.Colors {
+	colors := make([]tablewriter.Colors, len(row))
+	for i, header := range tb.headers {
+		if status := tb.cellStatus(header, row[i]); status != "" {
+			colors[i] = statusColor(status)
+		}
+	}
+	return colors
+}
+
+// cellStatus returns the support state shown by a cell, or an empty status for cells that are not colored.
+// Boolean eol and support values are taken literally and dates are compared with the warning window
+func (tb *TableBuilder) cellStatus(header, value string) eoldate.Status {
+	switch strings.ToLower(header) {
+	case "eol":
+		switch value {
+		case "true":
+			return eoldate.StatusEOL
+		case "false":
+			return eoldate.StatusSupported
+		}
+		return tb.dateStatus(value)
+	case "support":
+		switch value {
+		case "true":
+			return eoldate.StatusSupported
+		case "false":
+			return eoldate.StatusEOL
+		}
+		return tb.dateStatus(value)
+	case "extendedsupport":
+		return tb.dateStatus(value)
+	case eoldate.FieldStatus:
+		return eoldate.Status(value)
+	default:
+		return ""
+	}
+}
+
+// dateStatus returns the status of a cycle ending support on a date, as reported by check and the status column
+func (tb *TableBuilder) dateStatus(dateStr string) eoldate.Status {
+	date, err := tb.parseDate(dateStr)
+	if err != nil {
+		return ""
+	}
+	return eoldate.EOLDateStatus(date, tb.now, tb.warnDays)
+}
+
+// parseDate attempts to parse a date string in various formats
+func (tb *TableBuilder) parseDate(dateStr string) (time.Time, error) {
+	return eoldate.ParseDate(dateStr)
+}
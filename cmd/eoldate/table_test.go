@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mr-pmillz/eoldate"
+)
+
+func TestTableBuilder_SetColumns(t *testing.T) {
+	var products eoldate.Products
+	if err := json.Unmarshal([]byte(`[{"cycle":"24.04","codename":"Noble Numbat","releaseDate":"2024-04-25","eol":"2029-05-31","supportedPhpVersions":"8.2 - 8.3"}]`), &products); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name        string
+		columns     []string
+		wantHeaders []string
+		wantRow     []string
+		wantErr     string
+	}{
+		{
+			name:        "defaults",
+			wantHeaders: []string{"cycle", "releaseDate", "eol", "codename", "supportedPHPVersions"},
+			wantRow:     []string{"24.04", "2024-04-25", "2029-05-31", "Noble Numbat", "8.2 - 8.3"},
+		},
+		{
+			name:        "selected in order ignoring case and separators",
+			columns:     []string{"EOL", "release_date", "code-name", "cycle"},
+			wantHeaders: []string{"eol", "releaseDate", "codename", "cycle"},
+			wantRow:     []string{"2029-05-31", "2024-04-25", "Noble Numbat", "24.04"},
+		},
+		{
+			name:    "unknown column",
+			columns: []string{"cycle", "bogus"},
+			wantErr: `unknown column "bogus", available columns: cycle, releaseDate, eol,`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := NewTableBuilder(products).SetColor(false)
+			err := tb.SetColumns(tt.columns)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) || !strings.Contains(err.Error(), "codename") {
+					t.Fatalf("SetColumns(%v) error = %v, want %s... codename", tt.columns, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetColumns(%v) error = %v", tt.columns, err)
+			}
+			if !reflect.DeepEqual(tb.Headers(), tt.wantHeaders) {
+				t.Errorf("Headers() = %v, want %v", tb.Headers(), tt.wantHeaders)
+			}
+			if !reflect.DeepEqual(tb.rows[0], tt.wantRow) {
+				t.Errorf("row = %v, want %v", tb.rows[0], tt.wantRow)
+			}
+		})
+	}
+}
+
+func TestRunProduct_UnknownColumn(t *testing.T) {
+	cacheDir := writeCacheFixture(t, map[string]string{"php": phpFixture})
+	for _, format := range []string{"table", "csv", "xlsx"} {
+		var code int
+		out := captureStdout(t, func() {
+			code = run([]string{"product", "-cache-dir", cacheDir, "-format", format, "-columns", "cycle,bogus", "php"})
+		})
+		if code != exitUsage || out != "" {
+			t.Errorf("product -format %s -columns cycle,bogus = %d, %q, want usage error without output", format, code, out)
+		}
+	}
+}
+
+func TestTableBuilder_CellStatusMatchesAnnotation(t *testing.T) {
+	products := eoldate.Products{{Cycle: "8.1", EOL: "2024-09-01", Support: "2024-06-03"}}
+	for _, now := range []time.Time{
+		time.Date(2024, 6, 2, 12, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC),
+		time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		want := eoldate.Status(products.AnnotateWithin(now, 90)[0].AdditionalFields[eoldate.FieldStatus].(string))
+		tb := NewTableBuilder(products).SetNow(now).SetWarnDays(90)
+		if got := tb.cellStatus("eol", "2024-09-01"); got != want {
+			t.Errorf("cellStatus(eol) at %s = %s, want %s like the status column", now.Format(time.DateTime), got, want)
+		}
+	}
+}
+
+func TestTableBuilder_RowsResolveFieldsByName(t *testing.T) {
+	var products eoldate.Products
+	if err := json.Unmarshal([]byte(`[{"cycle":"24.04","codename":"Noble Numbat","eol":"2029-05-31","lts":true},{"cycle":"23.10"}]`), &products); err != nil {
+		t.Fatal(err)
+	}
+	tb := NewTableBuilder(products).SetColor(false)
+	if err := tb.SetColumns([]string{"cycle", "eol", "lts", "codename"}); err != nil {
+		t.Fatal(err)
+	}
+	// missing product fields are not available and missing additional fields are empty
+	want := [][]string{
+		{"24.04", "2029-05-31", "true", "Noble Numbat"},
+		{"23.10", eoldate.NotAvailable, eoldate.NotAvailable, ""},
+	}
+	if !reflect.DeepEqual(tb.rows, want) {
+		t.Errorf("rows = %q, want %q", tb.rows, want)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/mr-pmillz/eoldate"
+)
+
+// templateData is the data passed to -template and -template-string templates.
+// Products is set by the product command, Results by check and scan and Listings by list
+type templateData struct {
+	Name     string
+	Now      time.Time
+	WarnDays int
+	Products eoldate.Products
+	Results  []eoldate.CheckResult
+	Listings []listing
+}
+
+// templateColors are the ANSI colors available to the color template function
+var templateColors = map[string]string{
+	"red":     "\x1b[31m",
+	"green":   "\x1b[32m",
+	"yellow":  "\x1b[33m",
+	"blue":    "\x1b[34m",
+	"magenta": "\x1b[35m",
+	"cyan":    "\x1b[36m",
+	"bold":    "\x1b[1m",
+}
+
+// statusTemplateColors are the colors of each status, matching the table output
+var statusTemplateColors = map[eoldate.Status]string{
+	eoldate.StatusSupported:      "green",
+	eoldate.StatusApproachingEOL: "yellow",
+	eoldate.StatusEOL:            "red",
+	eoldate.StatusUnknown:        "magenta",
+}
+
+// parseTemplate parses the -template file or the -template-string, returning nil when neither is set
+func (g *globalOptions) parseTemplate() (*template.Template, error) {
+	switch {
+	case g.template != "" && g.templateString != "":
+		return nil, fmt.Errorf("-template and -template-string cannot be used together")
+	case g.template != "":
+		data, err := os.ReadFile(g.template)
+		if err != nil {
+			return nil, err
+		}
+		return template.New(g.template).Funcs(g.templateFuncs(eoldate.DefaultWarnDays)).Parse(string(data))
+	case g.templateString != "":
+		return template.New("template-string").Funcs(g.templateFuncs(eoldate.DefaultWarnDays)).Parse(g.templateString)
+	default:
+		return nil, nil
+	}
+}
+
+// executeTemplate renders data with the parsed -template or -template-string
+func (g *globalOptions) executeTemplate(w io.Writer, data templateData) error {
+	if data.Now.IsZero() {
+		data.Now = g.now()
+	}
+	// rebind the helpers to the -warn-days of the command
+	return g.tmpl.Funcs(g.templateFuncs(data.WarnDays)).Execute(w, data)
+}
+
+// templateFuncs returns the helper functions available to templates, evaluating statuses with warnDays
+func (g *globalOptions) templateFuncs(warnDays int) template.FuncMap {
+	color := g.color()
+	now := g.now
+	colorize := func(name, text string) string {
+		code, ok := templateColors[name]
+		if !color || !ok {
+			return text
+		}
+		return code + text + ansiReset
+	}
+	return template.FuncMap{
+		// date formats a date value with a Go time layout, e.g. {{date "Jan 2006" .EOL}}
+		"date": func(layout string, value interface{}) string {
+			if t, ok := templateTime(value); ok {
+				return t.Format(layout)
+			}
+			return fmt.Sprint(value)
+		},
+		// daysUntil returns the days from now until a date value, negative once passed, or 0 when value is not a date
+		"daysUntil": func(value interface{}) int {
+			t, ok := templateTime(value)
+			if !ok {
+				return 0
+			}
+			return int(startOfDay(t).Sub(startOfDay(now())).Hours() / 24)
+		},
+		// humanize describes the distance to a date value, e.g. "in 1y 3m"
+		"humanize": func(value interface{}) string {
+			if t, ok := templateTime(value); ok {
+				return eoldate.HumanizeDuration(t, now())
+			}
+			return ""
+		},
+		// status returns the support status of a cycle
+		"status": func(p eoldate.Product) eoldate.Status {
+			return p.AnnotationWithin(now(), warnDays).Status
+		},
+		// color wraps text in an ANSI color when colors are enabled
+		"color": colorize,
+		// statusColor wraps text in the color of a status
+		"statusColor": func(status eoldate.Status, text string) string {
+			return colorize(statusTemplateColors[status], text)
+		},
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"join":  strings.Join,
+		// json encodes a value as JSON
+		"json": func(v interface{}) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+	}
+}
+
+// templateTime converts a date string or time to a time
+func templateTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		t, err := eoldate.ParseDate(v)
+		return t, err == nil
+	default:
+		return time.Time{}, false
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mr-pmillz/eoldate"
+)
+
+func TestExecuteTemplate(t *testing.T) {
+	var products eoldate.Products
+	if err := json.Unmarshal([]byte(phpFixture), &products); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		template string
+		data     templateData
+		want     string
+	}{
+		{
+			name:     "status with the default warning window",
+			template: `{{range .Products}}{{.Cycle}}={{status .}} {{end}}`,
+			data:     templateData{WarnDays: eoldate.DefaultWarnDays, Products: products},
+			want:     "8.3=supported 8.1=approaching-eol 7.4=eol ",
+		},
+		{
+			name:     "status with -warn-days",
+			template: `{{range .Products}}{{.Cycle}}={{status .}} {{end}}`,
+			data:     templateData{WarnDays: 7, Products: products},
+			want:     "8.3=supported 8.1=supported 7.4=eol ",
+		},
+		{
+			name:     "date helpers",
+			template: `{{with index .Products 1}}{{date "Jan 2006" .EOL}} {{daysUntil .EOL}} {{humanize .EOL}}{{end}}`,
+			data:     templateData{Products: products},
+			want:     "Jan 2025 19 in 19d",
+		},
+		{
+			name:     "results",
+			template: `{{range .Results}}{{if ne .Status "supported"}}{{upper .Product}} {{.Version}}: {{statusColor .Status (printf "%s" .Status)}}{{"\n"}}{{end}}{{end}}`,
+			data:     templateData{Results: checkResultsFixture()},
+			want:     "PHP 7.4.33: eol\nPHP 8.1.30: approaching-eol\nNOPE 1.0: unknown\n",
+		},
+		{
+			name:     "json",
+			template: `{{json .Name}} {{.WarnDays}} {{.Now.Format "2006-01-02"}}`,
+			data:     templateData{Name: `php "8"`, WarnDays: 30},
+			want:     `"php \"8\"" 30 2025-01-01`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &globalOptions{templateString: tt.template, noColor: true, asOfTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+			tmpl, err := g.parseTemplate()
+			if err != nil {
+				t.Fatalf("parseTemplate() error = %v", err)
+			}
+			g.tmpl = tmpl
+			var buf bytes.Buffer
+			if err = g.executeTemplate(&buf, tt.data); err != nil {
+				t.Fatalf("executeTemplate() error = %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("executeTemplate() = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
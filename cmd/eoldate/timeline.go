@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mr-pmillz/eoldate"
+	"golang.org/x/term"
+)
+
+const (
+	defaultTimelineWidth = 100
+	minTimelineBarWidth  = 20
+)
+
+// timeline bar characters for each phase of a cycle
+const (
+	timelineActive   = '█'
+	timelineSecurity = '▓'
+	timelineExtended = '░'
+	timelineToday    = '│'
+)
+
+// ANSI colors of the timeline phases
+const (
+	ansiReset  = "\x1b[0m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+	ansiBlue   = "\x1b[34m"
+	ansiRed    = "\x1b[31m"
+)
+
+// timelinePhases are the bar characters and colors of the lifecycle phases
+var timelinePhases = map[eoldate.Phase]struct {
+	char  rune
+	color string
+}{
+	eoldate.PhaseActive:   {char: timelineActive, color: ansiGreen},
+	eoldate.PhaseSecurity: {char: timelineSecurity, color: ansiYellow},
+	eoldate.PhaseExtended: {char: timelineExtended, color: ansiBlue},
+}
+
+// writeProductsTimeline draws each cycle as a horizontal bar from its release through active support,
+// security support and extended support, scaled to the terminal width with a marker for today
+func writeProductsTimeline(w io.Writer, r *productReport) error {
+	now := r.table.now
+	var bars []eoldate.Lifecycle
+	start, end := now, now
+	for _, product := range r.products {
+		lc, ok := product.Lifecycle()
+		if !ok {
+			continue
+		}
+		for _, t := range lc.Dates() {
+			if t.Before(start) {
+				start = t
+			}
+			if t.After(end) {
+				end = t
+			}
+		}
+		bars = append(bars, lc)
+	}
+	if len(bars) == 0 {
+		_, err := fmt.Fprintf(w, "%s has no cycles with a release date\n", r.name)
+		return err
+	}
+	// leave room after the last date so open ended cycles are visibly open
+	end = end.AddDate(0, 6, 0)
+
+	labelWidth := len("cycle")
+	for _, bar := range bars {
+		labelWidth = max(labelWidth, len(bar.Cycle))
+	}
+	barWidth := max(terminalWidth()-labelWidth-3, minTimelineBarWidth)
+	column := func(t time.Time) int {
+		return int(float64(t.Sub(start)) / float64(end.Sub(start)) * float64(barWidth-1))
+	}
+	today := column(now)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%-*s  %s\n", labelWidth, r.name, timelineAxis(start, end, barWidth, column))
+	for _, bar := range bars {
+		cells := make([]rune, barWidth)
+		phases := make([]string, barWidth)
+		for i := range cells {
+			cells[i] = ' '
+		}
+		for _, phase := range bar.Phases(end) {
+			style := timelinePhases[phase.Phase]
+			for i := column(phase.Start); i <= column(phase.End) && i < barWidth; i++ {
+				cells[i], phases[i] = style.char, style.color
+			}
+		}
+		if today >= 0 && today < barWidth {
+			cells[today], phases[today] = timelineToday, ansiRed
+		}
+		fmt.Fprintf(&sb, "%-*s  %s\n", labelWidth, bar.Cycle, timelineRow(cells, phases, r.table.color))
+	}
+	fmt.Fprintf(&sb, "\n%-*s  %c active support  %c security support  %c extended support  %c today (%s)\n",
+		labelWidth, "", timelineActive, timelineSecurity, timelineExtended, timelineToday, now.Format("2006-01-02"))
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// timelineRow joins the cells of a bar, coloring runs of the same phase
+func timelineRow(cells []rune, phases []string, color bool) string {
+	if !color {
+		return strings.TrimRight(string(cells), " ")
+	}
+	var sb strings.Builder
+	current := ""
+	for i, cell := range cells {
+		if phases[i] != current {
+			if current != "" {
+				sb.WriteString(ansiReset)
+			}
+			sb.WriteString(phases[i])
+			current = phases[i]
+		}
+		sb.WriteRune(cell)
+	}
+	if current != "" {
+		sb.WriteString(ansiReset)
+	}
+	return strings.TrimRight(sb.String(), " ")
+}
+
+// timelineAxis returns a line labeling the start of each year that fits
+func timelineAxis(start, end time.Time, width int, column func(time.Time) int) string {
+	axis := []rune(strings.Repeat(" ", width))
+	next := 0
+	for year := start.Year() + 1; year <= end.Year(); year++ {
+		label := strconv.Itoa(year)
+		col := column(time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC))
+		if col < next || col+len(label) > width {
+			continue
+		}
+		copy(axis[col:], []rune(label))
+		next = col + len(label) + 1
+	}
+	return strings.TrimRight(string(axis), " ")
+}
+
+// terminalWidth returns the width of the terminal attached to stdout, the COLUMNS environment variable
+// or a default width when stdout is not a terminal
+func terminalWidth() int {
+	if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && width > 0 {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return defaultTimelineWidth
+}
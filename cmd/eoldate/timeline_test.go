@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mr-pmillz/eoldate"
+)
+
+// timelineFixture has cycles with security and extended support, an EOL without a date,
+// an open ended cycle and a cycle without a release date
+var timelineFixture = eoldate.Products{
+	{Cycle: "24.04", ReleaseDate: "2024-04-25", Support: "2026-06-01", EOL: "2029-05-31", ExtendedSupport: "2034-04-25"},
+	{Cycle: "22.04", ReleaseDate: "2022-04-21", Support: "2024-09-30", EOL: "2027-04-01"},
+	{Cycle: "rolling", ReleaseDate: "2023-01-01", EOL: false},
+	{Cycle: "14.04", ReleaseDate: "2020-01-01", Support: "2021-01-01", EOL: true},
+	{Cycle: "unreleased", EOL: "2030-01-01"},
+}
+
+func TestWriteProductsTimeline(t *testing.T) {
+	t.Setenv("COLUMNS", "70")
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	render := func(color bool) string {
+		var buf bytes.Buffer
+		report := &productReport{name: "ubuntu", products: timelineFixture, table: NewTableBuilder(timelineFixture).SetColor(color).SetNow(now)}
+		if err := writeProductsTimeline(&buf, report); err != nil {
+			t.Fatalf("writeProductsTimeline() error = %v", err)
+		}
+		return buf.String()
+	}
+
+	want := `ubuntu      2021    2023    2025    2027    2029    2031    2033
+24.04                     ██│█████▓▓▓▓▓▓▓▓▓▓▓▓░░░░░░░░░░░░░░░░░░░░░
+22.04             █████████▓│▓▓▓▓▓▓▓▓▓
+rolling             ████████│████████████████████████████████████████
+14.04    ████               │
+
+         █ active support  ▓ security support  ░ extended support  │ today (2025-01-01)
+`
+	if got := render(false); got != want {
+		t.Errorf("writeProductsTimeline() =\n%s\nwant\n%s", got, want)
+	}
+
+	colored := render(true)
+	for _, want := range []string{ansiGreen + "█", ansiYellow + "▓", ansiBlue + "░", ansiRed + "│" + ansiReset} {
+		if !strings.Contains(colored, want) {
+			t.Errorf("colored timeline does not contain %q", want)
+		}
+	}
+}
+
+func TestWriteProductsTimeline_NoReleaseDates(t *testing.T) {
+	var buf bytes.Buffer
+	products := eoldate.Products{{Cycle: "1.0", EOL: "2030-01-01"}}
+	if err := writeProductsTimeline(&buf, &productReport{name: "x", products: products, table: NewTableBuilder(products)}); err != nil {
+		t.Fatalf("writeProductsTimeline() error = %v", err)
+	}
+	if buf.String() != "x has no cycles with a release date\n" {
+		t.Errorf("writeProductsTimeline() = %q", buf.String())
+	}
+}
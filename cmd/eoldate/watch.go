@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mr-pmillz/eoldate"
+	"github.com/projectdiscovery/gologger"
+)
+
+// defaultWatchInterval is how often watch refreshes its products by default
+const defaultWatchInterval = 6 * time.Hour
+
+// runWatch periodically refreshes products and reports their changes as JSON lines
+func runWatch(g *globalOptions, args []string) int {
+	fs := g.newFlagSet("watch", "eoldate watch -products <product,...> [flags]")
+	products := fs.String("products", "", "comma separated products to watch")
+	interval := fs.Duration("interval", defaultWatchInterval, "how often to refresh the products, e.g. 30m or 6h")
+	warnDays := fs.Int("warn-days", eoldate.DefaultWarnDays, "report cycles reaching EOL within this many days as approaching EOL")
+	once := fs.Bool("once", false, "poll once and exit, e.g. when run from cron")
+	var notify notifyOptions
+	notify.register(fs)
+	usage := fs.Usage
+	fs.Usage = func() {
+		usage()
+		fmt.Fprintf(fs.Output(), "\nWrites one JSON event per line for added and removed cycles, new releases, changed dates\nand cycles reaching EOL or entering the -warn-days window, and sends them to the -webhook when set.\nWith -dry-run the webhook body is written instead of the events.\nThe first poll compares with the last snapshot in the cache.\nEach poll is kept in the cache, so -once runs report what changed since the previous run.\n")
+	}
+	if err := g.parseFlags(fs, args); err != nil {
+		return flagExitCode(err, exitUsage)
+	}
+	names := splitList(strings.ToLower(*products))
+	if len(names) == 0 || fs.NArg() != 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	if *interval <= 0 {
+		fmt.Fprintf(fs.Output(), "invalid -interval %s, must be positive\n", *interval)
+		return exitUsage
+	}
+	if g.format != "" && g.format != "jsonl" {
+		fmt.Fprintf(fs.Output(), "format %q does not support the watch command, events are written as jsonl\n", g.format)
+		return exitUsage
+	}
+	notifier, err := notify.notifier()
+	if err != nil {
+		fmt.Fprintln(fs.Output(), err)
+		return exitUsage
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	watcher := g.client().NewWatcher(names, eoldate.WatchOptions{WarnDays: warnDays})
+	enc := json.NewEncoder(os.Stdout)
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		events, errs := watcher.Poll(time.Now())
+		for name, err := range errs {
+			gologger.Error().Msgf("Failed to refresh %s: %v", name, err)
+		}
+		// with -dry-run the notification body replaces the events on stdout
+		for i := 0; i < len(events) && !notify.dryRun; i++ {
+			if err = enc.Encode(events[i]); err != nil {
+				gologger.Error().Msg(err.Error())
+				return exitError
+			}
+		}
+		if notifier != nil {
+			if err = notifier.Notify(events); err != nil {
+				gologger.Error().Msgf("Sending notification: %v", err)
+			}
+		}
+		if *once {
+			if len(errs) > 0 || err != nil {
+				return exitError
+			}
+			return exitOK
+		}
+		gologger.Info().Msgf("Reported %d events, next refresh at %s", len(events), time.Now().Add(*interval).Format(time.RFC3339))
+
+		select {
+		case <-ctx.Done():
+			return exitOK
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mr-pmillz/eoldate"
+	"github.com/xuri/excelize/v2"
+)
+
+// xlsxDateColumns are the product columns written as date cells
+var xlsxDateColumns = map[string]bool{
+	"releaseDate":       true,
+	"latestReleaseDate": true,
+	"eol":               true,
+	"support":           true,
+	"extendedSupport":   true,
+	"lts":               true,
+}
+
+// xlsxEOLBooleans is the boolean value of a deadline column that means the cycle is EOL,
+// matching the table colors: eol true and support false are EOL and extendedSupport booleans are not colored
+var xlsxEOLBooleans = map[string]string{
+	"eol":     "TRUE",
+	"support": "FALSE",
+}
+
+// xlsxDeadline is a date column colored by how close its dates are,
+// and as EOL when it holds the boolean eolBoolean unless that is empty
+type xlsxDeadline struct {
+	column     int
+	eolBoolean string
+}
+
+// xlsxSheet is a table written to one worksheet
+type xlsxSheet struct {
+	name    string
+	headers []string
+	rows    [][]interface{}
+	// dateColumns hold date cells, the ones in deadlineColumns are colored by how close their dates are
+	// and statusColumn is colored by its status text
+	dateColumns     []int
+	deadlineColumns []xlsxDeadline
+	statusColumn    int
+}
+
+// writeProductsXLSX writes the cycles of a product to a spreadsheet with one sheet for the product
+func writeProductsXLSX(w io.Writer, r *productReport) error {
+	columns := r.columns
+	if len(columns) == 0 {
+		columns = eoldate.ProductCSVColumns(r.products)
+	}
+	sheet := xlsxSheet{name: r.name, headers: columns, statusColumn: -1}
+	for i, column := range columns {
+		if xlsxDateColumns[column] {
+			sheet.dateColumns = append(sheet.dateColumns, i)
+		}
+		switch column {
+		case "eol", "support", "extendedSupport":
+			sheet.deadlineColumns = append(sheet.deadlineColumns, xlsxDeadline{column: i, eolBoolean: xlsxEOLBooleans[column]})
+		case eoldate.FieldStatus:
+			sheet.statusColumn = i
+		}
+	}
+	for _, product := range r.products {
+		row := make([]interface{}, len(columns))
+		for i, column := range columns {
+			row[i] = xlsxValue(product.Field(column), xlsxDateColumns[column])
+		}
+		sheet.rows = append(sheet.rows, row)
+	}
+	return writeXLSX(w, []xlsxSheet{sheet}, r.table.warnDays)
+}
+
+// writeCheckXLSX writes check results to a spreadsheet with one sheet for all results
+func writeCheckXLSX(w io.Writer, r *checkReport) error {
+	headers := checkReportColumns(r.results)
+	sheet := xlsxSheet{name: "results", headers: headers, statusColumn: -1}
+	for i, header := range headers {
+		switch header {
+		case "eol":
+			sheet.dateColumns = append(sheet.dateColumns, i)
+			sheet.deadlineColumns = append(sheet.deadlineColumns, xlsxDeadline{column: i, eolBoolean: xlsxEOLBooleans[header]})
+		case "status":
+			sheet.statusColumn = i
+		}
+	}
+	for _, result := range r.results {
+		cells := checkReportRow(result, len(headers))
+		row := make([]interface{}, len(cells))
+		for i, cell := range cells {
+			switch headers[i] {
+			case "eol":
+				row[i] = xlsxValue(cell, true)
+			case "days_to_eol":
+				if days, err := strconv.Atoi(cell); err == nil {
+					row[i] = days
+				}
+			default:
+				row[i] = cell
+			}
+		}
+		sheet.rows = append(sheet.rows, row)
+	}
+	return writeXLSX(w, []xlsxSheet{sheet}, r.warnDays)
+}
+
+// writeXLSX writes sheets with a styled and frozen header row, auto-filters, typed date cells
+// and conditional formatting of EOL and approaching EOL cells
+func writeXLSX(w io.Writer, sheets []xlsxSheet, warnDays int) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true, Color: "FFFFFF"},
+		Fill:      excelize.Fill{Type: "pattern", Color: []string{"305496"}, Pattern: 1},
+		Alignment: &excelize.Alignment{Vertical: "center"},
+	})
+	if err != nil {
+		return err
+	}
+	dateFormat := "yyyy-mm-dd"
+	dateStyle, err := f.NewStyle(&excelize.Style{CustomNumFmt: &dateFormat})
+	if err != nil {
+		return err
+	}
+	eolStyle, err := f.NewConditionalStyle(&excelize.Style{
+		Font: &excelize.Font{Color: "9C0006"},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"FFC7CE"}, Pattern: 1},
+	})
+	if err != nil {
+		return err
+	}
+	approachingStyle, err := f.NewConditionalStyle(&excelize.Style{
+		Font: &excelize.Font{Color: "9C5700"},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"FFEB9C"}, Pattern: 1},
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, sheet := range sheets {
+		name := xlsxSheetName(sheet.name)
+		if i == 0 {
+			if err = f.SetSheetName("Sheet1", name); err != nil {
+				return err
+			}
+		} else if _, err = f.NewSheet(name); err != nil {
+			return err
+		}
+
+		header := make([]interface{}, len(sheet.headers))
+		widths := make([]int, len(sheet.headers))
+		for j, h := range sheet.headers {
+			header[j] = h
+			widths[j] = len(h) + 4
+		}
+		if err = f.SetSheetRow(name, "A1", &header); err != nil {
+			return err
+		}
+		for j, row := range sheet.rows {
+			cell, _ := excelize.CoordinatesToCellName(1, j+2)
+			if err = f.SetSheetRow(name, cell, &row); err != nil {
+				return err
+			}
+			for k, value := range row {
+				text := fmt.Sprint(value)
+				if t, ok := value.(time.Time); ok {
+					text = t.Format("2006-01-02")
+				}
+				widths[k] = min(max(widths[k], len(text)+2), 60)
+			}
+		}
+
+		lastCol, _ := excelize.ColumnNumberToName(max(len(sheet.headers), 1))
+		lastRow := max(len(sheet.rows)+1, 2)
+		if err = f.SetCellStyle(name, "A1", lastCol+"1", headerStyle); err != nil {
+			return err
+		}
+		for j, width := range widths {
+			col, _ := excelize.ColumnNumberToName(j + 1)
+			if err = f.SetColWidth(name, col, col, float64(width)); err != nil {
+				return err
+			}
+		}
+		for _, j := range sheet.dateColumns {
+			col, _ := excelize.ColumnNumberToName(j + 1)
+			if err = f.SetCellStyle(name, col+"2", fmt.Sprintf("%s%d", col, lastRow), dateStyle); err != nil {
+				return err
+			}
+		}
+		if err = f.SetPanes(name, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"}); err != nil {
+			return err
+		}
+		if err = f.AutoFilter(name, fmt.Sprintf("A1:%s%d", lastCol, lastRow), nil); err != nil {
+			return err
+		}
+
+		for _, deadline := range sheet.deadlineColumns {
+			col, _ := excelize.ColumnNumberToName(deadline.column + 1)
+			first := col + "2"
+			eolCriteria := fmt.Sprintf("AND(ISNUMBER(%s),%s<TODAY())", first, first)
+			if deadline.eolBoolean != "" {
+				eolCriteria = fmt.Sprintf("OR(%s=%s,%s)", first, deadline.eolBoolean, eolCriteria)
+			}
+			err = f.SetConditionalFormat(name, fmt.Sprintf("%s:%s%d", first, col, lastRow), []excelize.ConditionalFormatOptions{
+				{Type: "formula", Criteria: eolCriteria, Format: &eolStyle},
+				{Type: "formula", Criteria: fmt.Sprintf("AND(ISNUMBER(%s),%s<TODAY()+%d)", first, first, warnDays), Format: &approachingStyle},
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if sheet.statusColumn >= 0 {
+			col, _ := excelize.ColumnNumberToName(sheet.statusColumn + 1)
+			err = f.SetConditionalFormat(name, fmt.Sprintf("%s2:%s%d", col, col, lastRow), []excelize.ConditionalFormatOptions{
+				{Type: "cell", Criteria: "==", Value: strconv.Quote(string(eoldate.StatusEOL)), Format: &eolStyle},
+				{Type: "cell", Criteria: "==", Value: strconv.Quote(string(eoldate.StatusApproachingEOL)), Format: &approachingStyle},
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return f.Write(w)
+}
+
+// xlsxValue converts a field value to a typed cell value, parsing dates when date is set
+func xlsxValue(value interface{}, date bool) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		if date {
+			if t, err := eoldate.ParseDate(v); err == nil {
+				return t
+			}
+		}
+		return v
+	case *float64:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case bool, int, float64:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// xlsxSheetName returns a valid worksheet name: at most 31 characters without []:*?/\
+func xlsxSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	if name == "" {
+		name = "eoldate"
+	}
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	return name
+}
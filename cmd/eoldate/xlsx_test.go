@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mr-pmillz/eoldate"
+	"github.com/xuri/excelize/v2"
+)
+
+// openXLSX reopens a written workbook
+func openXLSX(t *testing.T, buf *bytes.Buffer) *excelize.File {
+	t.Helper()
+	f, err := excelize.OpenReader(buf)
+	if err != nil {
+		t.Fatalf("excelize.OpenReader() error = %v", err)
+	}
+	t.Cleanup(func() { _ = f.Close() })
+	return f
+}
+
+// xlsxCriteria returns the criteria of the conditional formats of a column of a sheet, or of all columns when column is empty
+func xlsxCriteria(t *testing.T, f *excelize.File, sheet, column string) string {
+	t.Helper()
+	formats, err := f.GetConditionalFormats(sheet)
+	if err != nil {
+		t.Fatalf("GetConditionalFormats() error = %v", err)
+	}
+	var criteria []string
+	for cells, options := range formats {
+		if column != "" && !strings.HasPrefix(cells, column+"2:") {
+			continue
+		}
+		for _, option := range options {
+			criteria = append(criteria, option.Criteria+" "+option.Value)
+		}
+	}
+	return strings.Join(criteria, "\n")
+}
+
+func TestWriteProductsXLSX(t *testing.T) {
+	var products eoldate.Products
+	if err := json.Unmarshal([]byte(phpFixture), &products); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	products = products.AnnotateWithin(now, 7)
+	table := NewTableBuilder(products).SetNow(now).SetWarnDays(7)
+	report := &productReport{name: "php", products: products, table: table, columns: []string{"cycle", "eol", "latest", eoldate.FieldStatus}}
+	var buf bytes.Buffer
+	if err := writeProductsXLSX(&buf, report); err != nil {
+		t.Fatalf("writeProductsXLSX() error = %v", err)
+	}
+	f := openXLSX(t, &buf)
+
+	if sheets := f.GetSheetList(); len(sheets) != 1 || sheets[0] != "php" {
+		t.Fatalf("sheets = %q, want [php]", sheets)
+	}
+	rows, err := f.GetRows("php")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"cycle", "eol", "latest", "status"},
+		{"8.3", "2027-12-31", "8.3.12", "supported"},
+		{"8.1", "2025-01-20", "8.1.30", "supported"},
+		{"7.4", "2022-11-28", "7.4.33", "eol"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("rows = %q, want %q", rows, want)
+	}
+	for i := range want {
+		if strings.Join(rows[i], ",") != strings.Join(want[i], ",") {
+			t.Errorf("row %d = %q, want %q", i+1, rows[i], want[i])
+		}
+	}
+	if cellType, _ := f.GetCellType("php", "B3"); cellType == excelize.CellTypeInlineString || cellType == excelize.CellTypeSharedString {
+		t.Errorf("eol cell type = %v, want a date", cellType)
+	}
+	panes, err := f.GetPanes("php")
+	if err != nil || !panes.Freeze || panes.YSplit != 1 {
+		t.Errorf("panes = %+v, want the header row frozen", panes)
+	}
+	criteria := xlsxCriteria(t, f, "php", "")
+	for _, want := range []string{"AND(ISNUMBER(B2),B2<TODAY()+7)", `"eol"`, `"approaching-eol"`} {
+		if !strings.Contains(criteria, want) {
+			t.Errorf("conditional formats = %q, want %q", criteria, want)
+		}
+	}
+}
+
+func TestWriteCheckXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeCheckXLSX(&buf, &checkReport{results: checkResultsFixture(), warnDays: 30}); err != nil {
+		t.Fatalf("writeCheckXLSX() error = %v", err)
+	}
+	f := openXLSX(t, &buf)
+
+	if sheets := f.GetSheetList(); len(sheets) != 1 || sheets[0] != "results" {
+		t.Fatalf("sheets = %q, want [results]", sheets)
+	}
+	rows, err := f.GetRows("results")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != len(checkResultsFixture())+1 {
+		t.Fatalf("got %d rows, want a header and one row per result", len(rows))
+	}
+	header := strings.Join(rows[0], ",")
+	for _, want := range []string{"product", "version", "status", "eol", "days_to_eol"} {
+		if !strings.Contains(header, want) {
+			t.Errorf("header = %q, want a %q column", header, want)
+		}
+	}
+	if row := strings.Join(rows[1], ","); !strings.Contains(row, "7.4.33") || !strings.Contains(row, "eol") || !strings.Contains(row, "-765") {
+		t.Errorf("row 2 = %q, want the EOL result", row)
+	}
+	criteria := xlsxCriteria(t, f, "results", "")
+	for _, want := range []string{"<TODAY()+30)", `"approaching-eol"`} {
+		if !strings.Contains(criteria, want) {
+			t.Errorf("conditional formats = %q, want %q", criteria, want)
+		}
+	}
+}
+
+func TestWriteProductsXLSX_DeadlineCriteria(t *testing.T) {
+	products := eoldate.Products{
+		{Cycle: "24.04", Support: true, EOL: "2029-05-31", ExtendedSupport: true},
+		{Cycle: "14.04", Support: false, EOL: true, ExtendedSupport: "2024-04-25"},
+	}
+	report := &productReport{name: "ubuntu", products: products, table: NewTableBuilder(products), columns: []string{"cycle", "eol", "support", "extendedSupport"}}
+	var buf bytes.Buffer
+	if err := writeProductsXLSX(&buf, report); err != nil {
+		t.Fatalf("writeProductsXLSX() error = %v", err)
+	}
+	f := openXLSX(t, &buf)
+
+	tests := []struct {
+		column  string
+		want    []string
+		notWant []string
+	}{
+		{column: "B", want: []string{"OR(B2=TRUE,AND(ISNUMBER(B2),B2<TODAY()))", "AND(ISNUMBER(B2),B2<TODAY()+90)"}, notWant: []string{"=FALSE"}},
+		{column: "C", want: []string{"OR(C2=FALSE,AND(ISNUMBER(C2),C2<TODAY()))", "AND(ISNUMBER(C2),C2<TODAY()+90)"}, notWant: []string{"=TRUE"}},
+		{column: "D", want: []string{"AND(ISNUMBER(D2),D2<TODAY())", "AND(ISNUMBER(D2),D2<TODAY()+90)"}, notWant: []string{"=TRUE", "=FALSE"}},
+	}
+	for _, tt := range tests {
+		criteria := xlsxCriteria(t, f, "ubuntu", tt.column)
+		for _, want := range tt.want {
+			if !strings.Contains(criteria, want) {
+				t.Errorf("column %s conditional formats = %q, want %q", tt.column, criteria, want)
+			}
+		}
+		for _, notWant := range tt.notWant {
+			if strings.Contains(criteria, notWant) {
+				t.Errorf("column %s conditional formats = %q, must not contain %q", tt.column, criteria, notWant)
+			}
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package eoldate
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// CSVOptions controls how WriteProductsCSV writes cycles
+type CSVOptions struct {
+	// Comma is the field delimiter, defaults to ','
+	Comma rune
+	// Columns selects the columns and their order, defaults to ProductCSVColumns
+	Columns []string
+}
+
+// ProductCSVColumns returns the CSV columns of cycles: every Product field in declaration order
+// followed by the union of the AdditionalFields keys of all cycles in alphabetical order
+func ProductCSVColumns(products Products) []string {
+	columns := append([]string{}, productColumns...)
+	seen := make(map[string]bool)
+	var additional []string
+	for _, product := range products {
+		for key := range product.AdditionalFields {
+			if !isKnownProductField(key) && !seen[key] {
+				seen[key] = true
+				additional = append(additional, key)
+			}
+		}
+	}
+	sort.Strings(additional)
+	return append(columns, additional...)
+}
+
+// WriteProductsCSV writes cycles as CSV with a header row.
+// Missing values are empty, booleans are true or false, numbers have no trailing zeros
+// and lists or objects are written as JSON
+func WriteProductsCSV(w io.Writer, products Products, opts CSVOptions) error {
+	columns := opts.Columns
+	if len(columns) == 0 {
+		columns = ProductCSVColumns(products)
+	}
+	cw := csv.NewWriter(w)
+	if opts.Comma != 0 {
+		cw.Comma = opts.Comma
+	}
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+	for _, product := range products {
+		row := make([]string, len(columns))
+		var err error
+		for i, column := range columns {
+			if row[i], err = csvValue(product.Field(column)); err != nil {
+				return fmt.Errorf("cycle %s column %s: %w", product.Cycle, column, err)
+			}
+		}
+		if err = cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteProductsCSVFile writes cycles as CSV to outputFile, creating its directory and replacing any previous content
+func WriteProductsCSVFile(products Products, outputFile string) error {
+	if err := os.MkdirAll(filepath.Dir(outputFile), 0750); err != nil {
+		return LogError(err)
+	}
+	f, err := os.OpenFile(outputFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644) //nolint:gosec
+	if err != nil {
+		return LogError(err)
+	}
+	if err = WriteProductsCSV(f, products, CSVOptions{}); err != nil {
+		_ = f.Close()
+		return LogError(err)
+	}
+	return f.Close()
+}
+
+// Field returns the value of a cycle field by its JSON name, or of the AdditionalFields entry with that name
+func (p Product) Field(name string) interface{} {
+	if index, ok := productFieldIndex[name]; ok {
+		return reflect.ValueOf(p).Field(index).Interface()
+	}
+	return p.AdditionalFields[name]
+}
+
+// csvValue normalizes a field value for CSV output
+func csvValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case *float64:
+		if v == nil {
+			return "", nil
+		}
+		return strconv.FormatFloat(*v, 'f', -1, 64), nil
+	default:
+		data, err := json.Marshal(v)
+		return string(data), err
+	}
+}
@@ -0,0 +1,64 @@
+package eoldate
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteProductsCSV(t *testing.T) {
+	java := 17.0
+	products := Products{
+		{Cycle: "3.2", EOL: "2025-11-24", LTS: true, MinJavaVersion: &java, AdditionalFields: map[string]interface{}{"codename": "x, y"}},
+		{Cycle: "2.7", EOL: false, SupportedPHPVersions: []interface{}{"8.1", "8.2"}, AdditionalFields: map[string]interface{}{"discontinued": true}},
+	}
+	tests := []struct {
+		name string
+		opts CSVOptions
+		want string
+	}{
+		{
+			name: "default columns",
+			opts: CSVOptions{},
+			want: "cycle,releaseDate,eol,latest,link,latestReleaseDate,lts,support,extendedSupport,minJavaVersion,supportedPHPVersions,codename,discontinued\n" +
+				"3.2,,2025-11-24,,,,true,,,17,,\"x, y\",\n" +
+				"2.7,,false,,,,,,,,\"[\"\"8.1\"\",\"\"8.2\"\"]\",,true\n",
+		},
+		{
+			name: "selected columns and delimiter",
+			opts: CSVOptions{Comma: '\t', Columns: []string{"cycle", "discontinued", "eol"}},
+			want: "cycle\tdiscontinued\teol\n3.2\t\t2025-11-24\n2.7\ttrue\tfalse\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteProductsCSV(&buf, products, tt.opts); err != nil {
+				t.Fatalf("WriteProductsCSV() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("WriteProductsCSV() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteStructToCSVFile_Truncates(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "out", "products.csv")
+	long := Products{{Cycle: "3"}, {Cycle: "2"}, {Cycle: "1"}}
+	if err := WriteStructToCSVFile(long, outputFile); err != nil {
+		t.Fatalf("WriteStructToCSVFile() error = %v", err)
+	}
+	if err := WriteStructToCSVFile(long[:1], outputFile); err != nil {
+		t.Fatalf("WriteStructToCSVFile() error = %v", err)
+	}
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 2 {
+		t.Errorf("WriteStructToCSVFile() left %d lines, want 2:\n%s", lines, data)
+	}
+}
@@ -0,0 +1,79 @@
+package eoldate
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ChangeKind describes how a release cycle changed between two snapshots of a product
+type ChangeKind string
+
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "changed"
+)
+
+// diffFields are the JSON names of the cycle fields compared by DiffProducts, in the order changes are reported
+var diffFields = []string{"latest", "lts", "support", "eol", "extendedSupport"}
+
+// ProductChange is a difference between two snapshots of a product
+type ProductChange struct {
+	Kind  ChangeKind `json:"kind"`
+	Cycle string     `json:"cycle"`
+	// Field is the JSON name of the changed field, empty for added and removed cycles
+	Field string      `json:"field,omitempty"`
+	Old   interface{} `json:"old,omitempty"`
+	New   interface{} `json:"new,omitempty"`
+}
+
+// String describes the change, e.g. "22.04 eol changed from 2027-04-01 to 2027-06-01"
+func (c ProductChange) String() string {
+	switch c.Kind {
+	case ChangeAdded, ChangeRemoved:
+		return fmt.Sprintf("%s %s", c.Cycle, c.Kind)
+	default:
+		return fmt.Sprintf("%s %s changed from %s to %s", c.Cycle, c.Field, changeValue(c.Old), changeValue(c.New))
+	}
+}
+
+// DiffProducts compares two snapshots of a product and reports added and removed cycles
+// and changes of the latest version, LTS, support, EOL and extended support values.
+// Changes are ordered like the cycles of new, followed by removed cycles
+func DiffProducts(old, new Products) []ProductChange {
+	oldCycles := make(map[string]Product, len(old))
+	for _, product := range old {
+		oldCycles[product.Cycle] = product
+	}
+	newCycles := make(map[string]bool, len(new))
+
+	var changes []ProductChange
+	for _, product := range new {
+		newCycles[product.Cycle] = true
+		previous, ok := oldCycles[product.Cycle]
+		if !ok {
+			changes = append(changes, ProductChange{Kind: ChangeAdded, Cycle: product.Cycle})
+			continue
+		}
+		for _, field := range diffFields {
+			oldValue, newValue := previous.Field(field), product.Field(field)
+			if !reflect.DeepEqual(oldValue, newValue) {
+				changes = append(changes, ProductChange{Kind: ChangeModified, Cycle: product.Cycle, Field: field, Old: oldValue, New: newValue})
+			}
+		}
+	}
+	for _, product := range old {
+		if !newCycles[product.Cycle] {
+			changes = append(changes, ProductChange{Kind: ChangeRemoved, Cycle: product.Cycle})
+		}
+	}
+	return changes
+}
+
+// changeValue formats a changed value, describing missing values as none
+func changeValue(value interface{}) string {
+	if value == nil || value == "" {
+		return "none"
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,75 @@
+package eoldate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffProducts(t *testing.T) {
+	old := Products{
+		{Cycle: "24.04", Latest: "24.04.1", LTS: true, Support: "2029-05-31", EOL: "2029-05-31"},
+		{Cycle: "22.04", Latest: "22.04.4", LTS: true, Support: "2027-06-01", EOL: "2027-04-01"},
+		{Cycle: "23.04", Latest: "23.04", EOL: "2024-01-25"},
+	}
+	tests := []struct {
+		name string
+		new  Products
+		want []ProductChange
+	}{
+		{
+			name: "unchanged",
+			new:  old,
+			want: nil,
+		},
+		{
+			name: "added, changed and removed",
+			new: Products{
+				{Cycle: "24.10", Latest: "24.10", EOL: "2025-07-10"},
+				{Cycle: "24.04", Latest: "24.04.2", LTS: true, Support: "2029-05-31", EOL: "2029-05-31"},
+				{Cycle: "22.04", Latest: "22.04.4", LTS: true, Support: "2027-06-01", EOL: "2027-06-01", ExtendedSupport: "2032-04-09"},
+			},
+			want: []ProductChange{
+				{Kind: ChangeAdded, Cycle: "24.10"},
+				{Kind: ChangeModified, Cycle: "24.04", Field: "latest", Old: "24.04.1", New: "24.04.2"},
+				{Kind: ChangeModified, Cycle: "22.04", Field: "eol", Old: "2027-04-01", New: "2027-06-01"},
+				{Kind: ChangeModified, Cycle: "22.04", Field: "extendedSupport", Old: nil, New: "2032-04-09"},
+				{Kind: ChangeRemoved, Cycle: "23.04"},
+			},
+		},
+		{
+			name: "date replaced by boolean",
+			new: Products{
+				{Cycle: "24.04", Latest: "24.04.1", LTS: true, Support: "2029-05-31", EOL: "2029-05-31"},
+				{Cycle: "22.04", Latest: "22.04.4", LTS: true, Support: "2027-06-01", EOL: "2027-04-01"},
+				{Cycle: "23.04", Latest: "23.04", EOL: true},
+			},
+			want: []ProductChange{
+				{Kind: ChangeModified, Cycle: "23.04", Field: "eol", Old: "2024-01-25", New: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DiffProducts(old, tt.new); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffProducts() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProductChange_String(t *testing.T) {
+	tests := []struct {
+		change ProductChange
+		want   string
+	}{
+		{ProductChange{Kind: ChangeAdded, Cycle: "24.10"}, "24.10 added"},
+		{ProductChange{Kind: ChangeRemoved, Cycle: "23.04"}, "23.04 removed"},
+		{ProductChange{Kind: ChangeModified, Cycle: "22.04", Field: "eol", Old: "2027-04-01", New: "2027-06-01"}, "22.04 eol changed from 2027-04-01 to 2027-06-01"},
+		{ProductChange{Kind: ChangeModified, Cycle: "22.04", Field: "extendedSupport", New: "2032-04-09"}, "22.04 extendedSupport changed from none to 2032-04-09"},
+	}
+	for _, tt := range tests {
+		if got := tt.change.String(); got != tt.want {
+			t.Errorf("ProductChange.String() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -1,12 +1,16 @@
 package eoldate
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/Masterminds/semver/v3"
 	"io"
 	"net/http"
+	"reflect"
 	"slices"
+	"sort"
 	"strings"
 	"time"
 )
@@ -41,6 +45,95 @@ type Product struct {
 	AdditionalFields     map[string]interface{} `json:"-"`
 }
 
+// productFields has the fields of Product without its JSON methods
+type productFields Product
+
+// productFieldIndex maps the JSON names of the fields declared on Product to their field index
+// and productColumns has the same names in declaration order
+var productFieldIndex, productColumns = func() (map[string]int, []string) {
+	index := make(map[string]int)
+	var columns []string
+	t := reflect.TypeOf(Product{})
+	for i := 0; i < t.NumField(); i++ {
+		if name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]; name != "" && name != "-" {
+			index[name] = i
+			columns = append(columns, name)
+		}
+	}
+	return index, columns
+}()
+
+// knownProductFields are the lowercased JSON names of the fields declared on Product
+var knownProductFields = func() map[string]bool {
+	known := make(map[string]bool, len(productColumns))
+	for _, name := range productColumns {
+		known[strings.ToLower(name)] = true
+	}
+	return known
+}()
+
+// isKnownProductField reports whether a JSON key is decoded into a Product field.
+// Keys are matched ignoring case like encoding/json does, so supportedPhpVersions is SupportedPHPVersions
+func isKnownProductField(name string) bool {
+	return knownProductFields[strings.ToLower(name)]
+}
+
+// UnmarshalJSON decodes the known fields of a cycle and keeps all other fields in AdditionalFields
+func (p *Product) UnmarshalJSON(data []byte) error {
+	var fields productFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for name := range raw {
+		if isKnownProductField(name) {
+			delete(raw, name)
+		}
+	}
+	*p = Product(fields)
+	if len(raw) > 0 {
+		p.AdditionalFields = raw
+	}
+	return nil
+}
+
+// MarshalJSON encodes the known fields of a cycle followed by its AdditionalFields in alphabetical order
+func (p Product) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(productFields(p))
+	if err != nil || len(p.AdditionalFields) == 0 {
+		return data, err
+	}
+
+	keys := make([]string, 0, len(p.AdditionalFields))
+	for key := range p.AdditionalFields {
+		if !isKnownProductField(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1])
+	for _, key := range keys {
+		value, err := json.Marshal(p.AdditionalFields[key])
+		if err != nil {
+			return nil, err
+		}
+		name, _ := json.Marshal(key)
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
 // IsSupportedSoftwareVersion checks if a given software version is supported and returns relevant information
 func (c *Client) IsSupportedSoftwareVersion(softwareName string, version string) (bool, *semver.Version, *Product, error) {
 	softwareReleaseData, err := c.GetProduct(strings.ToLower(softwareName))
@@ -61,11 +154,33 @@ func (c *Client) IsSupportedSoftwareVersion(softwareName string, version string)
 	return isSupported, latestVersion, matchingProduct, nil
 }
 
-// IsVersionSupported checks if the given version is supported in any of the product cycles
+// IsVersionSupported checks if the given version is supported in any of the product cycles.
+// A version that matches no cycle is reported as not supported without a cycle or an error
 func (p Products) IsVersionSupported(versionStr string) (bool, *Product, error) {
+	product, belowLowest, err := p.matchCycle(versionStr)
+	if errors.Is(err, ErrNoCycleMatch) {
+		return false, nil, nil
+	}
+	if err != nil {
+		return false, product, err
+	}
+	if belowLowest {
+		return false, product, nil
+	}
+
+	eolDate, err := product.GetEOLDate()
+	if err != nil {
+		return false, product, err
+	}
+	return time.Now().Before(eolDate), product, nil
+}
+
+// matchCycle returns the product cycle matching the given version.
+// Versions older than every known cycle return the lowest cycle with belowLowest set to true
+func (p Products) matchCycle(versionStr string) (product *Product, belowLowest bool, err error) {
 	version, err := semver.NewVersion(versionStr)
 	if err != nil {
-		return false, nil, fmt.Errorf("invalid version string: %s", versionStr)
+		return nil, false, fmt.Errorf("%w: %s", ErrInvalidVersion, versionStr)
 	}
 
 	var lowestCycle *semver.Version
@@ -83,7 +198,7 @@ func (p Products) IsVersionSupported(versionStr string) (bool, *Product, error)
 	}
 
 	if lowestCycle != nil && version.LessThan(lowestCycle) {
-		return false, lowestProduct, nil
+		return lowestProduct, true, nil
 	}
 
 	for _, product := range p {
@@ -93,14 +208,10 @@ func (p Products) IsVersionSupported(versionStr string) (bool, *Product, error)
 		}
 
 		if constraint.Check(version) {
-			eolDate, err := product.GetEOLDate()
-			if err != nil {
-				return false, &product, err
-			}
-			return time.Now().Before(eolDate), &product, nil
+			return &product, false, nil
 		}
 	}
-	return false, nil, nil
+	return nil, false, fmt.Errorf("%w: %s", ErrNoCycleMatch, versionStr)
 }
 
 // GetLatestSupportedVersion returns the latest supported version from a list of Products
@@ -119,7 +230,7 @@ func (p Products) GetLatestSupportedVersion() (*semver.Version, error) {
 		}
 	}
 	if latestVersion == nil {
-		return nil, fmt.Errorf("no valid versions found")
+		return nil, fmt.Errorf("%w: no valid versions found", ErrNoCycleMatch)
 	}
 	return latestVersion, nil
 }
@@ -151,24 +262,17 @@ func (p *Product) GetEndDate() *time.Time {
 func (p *Product) GetEOLDate() (time.Time, error) {
 	switch eol := p.EOL.(type) {
 	case string:
-		formats := []string{
-			"2006-01-02",
-			"2006-01",
-			"2006",
-		}
-		for _, format := range formats {
-			if t, err := time.Parse(format, eol); err == nil {
-				return t, nil
-			}
+		if t, err := ParseDate(eol); err == nil {
+			return t, nil
 		}
-		return time.Time{}, fmt.Errorf("unable to parse EOL date: %s", eol)
+		return time.Time{}, fmt.Errorf("%w: unable to parse EOL date: %s", ErrInvalidDate, eol)
 	case bool:
 		if eol {
 			return time.Now().AddDate(-1, 0, 0), nil // Assume EOL was a year ago if true
 		}
 		return time.Now().AddDate(100, 0, 0), nil // Assume far in the future if false
 	default:
-		return time.Time{}, fmt.Errorf("unexpected EOL type: %T", p.EOL)
+		return time.Time{}, fmt.Errorf("%w: unexpected EOL type: %T", ErrInvalidDate, p.EOL)
 	}
 }
 
@@ -178,14 +282,43 @@ type AllProducts []string
 type Client struct {
 	httpClient *http.Client
 	baseURL    string
+	cacheDir   string
 }
 
-// NewClient creates a new API client with the given base URL.
-func NewClient() *Client {
-	return &Client{
+// ClientOption configures optional settings of a Client
+type ClientOption func(*Client)
+
+// WithCacheDir sets the directory used to cache API responses instead of ~/.config/eoldate/cache
+func WithCacheDir(dir string) ClientOption {
+	return func(c *Client) {
+		c.cacheDir = dir
+	}
+}
+
+// WithHTTPClient sets the http.Client used to call the API
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithBaseURL sets the base URL of the API
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// NewClient creates a new API client with the given options.
+func NewClient(opts ...ClientOption) *Client {
+	c := &Client{
 		httpClient: &http.Client{},
 		baseURL:    EOLBaseURL,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // Get fetches data from a given endpoint.
@@ -193,12 +326,12 @@ func (c *Client) Get(endpoint string) ([]byte, error) {
 	url := fmt.Sprintf("%s/%s", c.baseURL, endpoint)
 	resp, err := c.httpClient.Get(url)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to fetch data from %s: %w", url, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch data: %s", resp.Status)
+		return nil, &HTTPError{URL: url, StatusCode: resp.StatusCode, Status: resp.Status}
 	}
 
 	return io.ReadAll(resp.Body)
@@ -215,34 +348,59 @@ func (c *Client) GetProduct(product string) (Products, error) {
 	}
 	if slices.Contains(allProducts, product) {
 		var products Products
-		productCache, err := readCache(product)
+		productCache, err := c.readCache(product)
 		if err != nil {
 			return nil, err
 		}
 		if productCache != nil {
-			err = json.Unmarshal(productCache, &products)
-			return products, err
-		}
-		data, err := c.Get(fmt.Sprintf("%s.json", product))
-		if err != nil {
-			return nil, err
-		}
-
-		if err = json.Unmarshal(data, &products); err != nil {
-			return nil, err
-		}
-		if err = writeCache(product, data); err != nil {
-			return nil, err
+			if err = json.Unmarshal(productCache, &products); err != nil {
+				cacheFile, _ := c.productCacheFile(product)
+				return nil, &CacheError{Op: "decode", Path: cacheFile, Err: err}
+			}
+			return products, nil
 		}
-		return products, err
+		return c.fetchProduct(product)
 	} else {
-		return nil, fmt.Errorf("product %s not found", product)
+		return nil, fmt.Errorf("%w: %s", ErrProductNotFound, product)
+	}
+}
+
+// RefreshProduct fetches the end-of-life information for a specific product from the API
+// even when it is cached today, updating the cache and recording a snapshot when it changed
+func (c *Client) RefreshProduct(product string) (Products, error) {
+	allProducts, err := c.CacheTechnologies()
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(allProducts, product) {
+		return nil, fmt.Errorf("%w: %s", ErrProductNotFound, product)
+	}
+	return c.fetchProduct(product)
+}
+
+// fetchProduct fetches a product from the API and writes it to the cache and history
+func (c *Client) fetchProduct(product string) (Products, error) {
+	data, err := c.Get(fmt.Sprintf("%s.json", product))
+	if err != nil {
+		return nil, err
+	}
+
+	var products Products
+	if err = json.Unmarshal(data, &products); err != nil {
+		return nil, err
+	}
+	if err = c.writeCache(product, data); err != nil {
+		return nil, err
+	}
+	if err = c.writeSnapshot(product, data, time.Now()); err != nil {
+		return nil, err
 	}
+	return products, nil
 }
 
 // GetAllProducts fetches the end-of-life information for all products.
 func (c *Client) GetAllProducts() (AllProducts, error) {
-	allProductsCache, err := readAllTechnologiesCache()
+	allProductsCache, err := c.readAllTechnologiesCache()
 	if err != nil {
 		return nil, LogError(err)
 	}
@@ -1,6 +1,8 @@
 package eoldate
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 )
@@ -120,3 +122,41 @@ func TestCalculateTimeDifference(t *testing.T) {
 		})
 	}
 }
+
+func TestProduct_JSONAdditionalFields(t *testing.T) {
+	data := `{"cycle":"24.04","codename":"Noble Numbat","eol":"2029-05-31","supportedPhpVersions":"8.2 - 8.3"}`
+	var p Product
+	if err := json.Unmarshal([]byte(data), &p); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if p.Cycle != "24.04" || p.EOL != "2029-05-31" {
+		t.Errorf("Unmarshal() known fields = %q, %v", p.Cycle, p.EOL)
+	}
+	if p.SupportedPHPVersions != "8.2 - 8.3" {
+		t.Errorf("Unmarshal() SupportedPHPVersions = %v, want the supportedPhpVersions key", p.SupportedPHPVersions)
+	}
+	if got := len(p.AdditionalFields); got != 1 || p.AdditionalFields["codename"] != "Noble Numbat" {
+		t.Errorf("Unmarshal() AdditionalFields = %v, want only codename", p.AdditionalFields)
+	}
+
+	encoded, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var roundTrip Product
+	if err = json.Unmarshal(encoded, &roundTrip); err != nil {
+		t.Fatalf("Unmarshal() of %s error = %v", encoded, err)
+	}
+	if roundTrip.SupportedPHPVersions != "8.2 - 8.3" || roundTrip.AdditionalFields["codename"] != "Noble Numbat" || roundTrip.Cycle != "24.04" {
+		t.Errorf("Marshal() = %s, lost fields", encoded)
+	}
+	if strings.Contains(string(encoded), "supportedPhpVersions") {
+		t.Errorf("Marshal() = %s, want supportedPHPVersions only once", encoded)
+	}
+
+	// a known field set in AdditionalFields with different case is not written twice
+	p.AdditionalFields["Cycle"] = "22.04"
+	if encoded, err = json.Marshal(p); err != nil || strings.Count(strings.ToLower(string(encoded)), `"cycle"`) != 1 {
+		t.Errorf("Marshal() = %s, %v, want one cycle", encoded, err)
+	}
+}
@@ -0,0 +1,52 @@
+package eoldate
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrProductNotFound is returned when a product is not listed by the endoflife.date API
+	ErrProductNotFound = errors.New("product not found")
+	// ErrInvalidVersion is returned when a version string cannot be parsed as a semantic version
+	ErrInvalidVersion = errors.New("invalid version string")
+	// ErrNoCycleMatch is returned when no release cycle of a product matches or can be parsed
+	ErrNoCycleMatch = errors.New("no matching release cycle")
+	// ErrInvalidDate is returned when a date field has an unexpected type or format
+	ErrInvalidDate = errors.New("invalid date")
+)
+
+// HTTPError is returned when the endoflife.date API responds with a non 200 status code
+type HTTPError struct {
+	URL        string
+	StatusCode int
+	Status     string
+}
+
+// Error ...
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("failed to fetch data from %s: %s", e.URL, e.Status)
+}
+
+// Temporary reports whether the request may succeed if retried
+func (e *HTTPError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// CacheError is returned when reading or writing the local file cache fails
+type CacheError struct {
+	Op   string
+	Path string
+	Err  error
+}
+
+// Error ...
+func (e *CacheError) Error() string {
+	return fmt.Sprintf("cache %s %s: %v", e.Op, e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *CacheError) Unwrap() error {
+	return e.Err
+}
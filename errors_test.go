@@ -0,0 +1,94 @@
+package eoldate
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewClient(WithBaseURL(server.URL), WithCacheDir(t.TempDir()))
+}
+
+func TestClient_GetProductErrors(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/all.json":
+			_, _ = w.Write([]byte(`["php","broken"]`))
+		case "/php.json":
+			_, _ = w.Write([]byte(`[{"cycle":"8.3","eol":"2027-12-31","latest":"8.3.12"}]`))
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+
+	if _, err := c.GetProduct("php"); err != nil {
+		t.Fatalf("GetProduct() unexpected error = %v", err)
+	}
+
+	_, err := c.GetProduct("nope")
+	if !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("GetProduct() error = %v, want ErrProductNotFound", err)
+	}
+
+	_, err = c.GetProduct("broken")
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("GetProduct() error = %v, want *HTTPError", err)
+	}
+	if httpErr.StatusCode != http.StatusServiceUnavailable || !httpErr.Temporary() {
+		t.Errorf("HTTPError = %+v, want temporary 503", httpErr)
+	}
+	cacheFile, err := c.productCacheFile("php")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(cacheFile, []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.GetProduct("php")
+	var cacheErr *CacheError
+	if !errors.As(err, &cacheErr) || cacheErr.Op != "decode" || cacheErr.Path != cacheFile {
+		t.Errorf("GetProduct() error = %v, want a decode CacheError of %s", err, cacheFile)
+	}
+}
+
+func TestProducts_IsVersionSupportedErrors(t *testing.T) {
+	products := Products{
+		{Cycle: "8.3", EOL: "2027-12-31"},
+		{Cycle: "8.2", EOL: true},
+	}
+	tests := []struct {
+		name    string
+		version string
+		wantErr error
+	}{
+		{name: "invalid version", version: "not-a-version", wantErr: ErrInvalidVersion},
+		{name: "no matching cycle is not an error", version: "9.1", wantErr: nil},
+		{name: "matching cycle", version: "8.3.1", wantErr: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := products.IsVersionSupported(tt.version)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("IsVersionSupported() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if supported, product, err := products.IsVersionSupported("9.1"); supported || product != nil || err != nil {
+		t.Errorf("IsVersionSupported(9.1) = %v, %v, %v, want false, nil, nil", supported, product, err)
+	}
+	if result := products.Check("php", "9.1", CheckOptions{}); !errors.Is(result.Err, ErrNoCycleMatch) || result.Status != StatusUnknown {
+		t.Errorf("Check(9.1) = %+v, want an unknown result with ErrNoCycleMatch", result)
+	}
+
+	if _, err := (&Product{EOL: 42.0}).GetEOLDate(); !errors.Is(err, ErrInvalidDate) {
+		t.Errorf("GetEOLDate() error = %v, want ErrInvalidDate", err)
+	}
+}
@@ -0,0 +1,98 @@
+package eoldate
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventKind describes what happened to a release cycle
+type EventKind string
+
+const (
+	EventCycleAdded     EventKind = "cycle-added"
+	EventCycleRemoved   EventKind = "cycle-removed"
+	EventNewRelease     EventKind = "new-release"
+	EventDateChanged    EventKind = "date-changed"
+	EventApproachingEOL EventKind = "approaching-eol"
+	EventEOL            EventKind = "eol"
+)
+
+// Event is a change of a release cycle reported by a Watcher, or a finding of a check passed to notifiers
+type Event struct {
+	Kind    EventKind `json:"kind"`
+	Time    time.Time `json:"time"`
+	Product string    `json:"product"`
+	Cycle   string    `json:"cycle"`
+	// Version, RecommendedUpgrade and Source are set for events of checked versions
+	Version            string `json:"version,omitempty"`
+	RecommendedUpgrade string `json:"recommendedUpgrade,omitempty"`
+	Source             string `json:"source,omitempty"`
+	// Field, Old and New are set for new releases and changed dates
+	Field  string      `json:"field,omitempty"`
+	Old    interface{} `json:"old,omitempty"`
+	New    interface{} `json:"new,omitempty"`
+	Status Status      `json:"status,omitempty"`
+	// EOLDate and DaysToEOL are set when the cycle has an EOL date
+	EOLDate   string `json:"eolDate,omitempty"`
+	DaysToEOL *int   `json:"daysToEOL,omitempty"`
+	Link      string `json:"link,omitempty"`
+	Message   string `json:"message"`
+}
+
+// CheckEvents returns an event for each checked version that reached EOL or is approaching EOL at a point in time.
+// Supported versions and versions that could not be checked have no event
+func CheckEvents(results []CheckResult, at time.Time) []Event {
+	var events []Event
+	for _, result := range results {
+		event := Event{
+			Time:               at,
+			Product:            result.Product,
+			Cycle:              result.Cycle,
+			Version:            result.Version,
+			Status:             result.Status,
+			EOLDate:            result.EOLDate,
+			DaysToEOL:          result.DaysUntilEOL,
+			RecommendedUpgrade: result.RecommendedUpgrade,
+			Link:               result.Link,
+			Source:             result.Source,
+		}
+		switch result.Status {
+		case StatusEOL:
+			event.Kind = EventEOL
+			event.Message = fmt.Sprintf("%s %s reached its end of life", result.Product, result.Version)
+			if result.EOLDate != "" {
+				event.Message += " on " + result.EOLDate
+			}
+		case StatusApproachingEOL:
+			event.Kind = EventApproachingEOL
+			event.Message = fmt.Sprintf("%s %s reaches its end of life on %s", result.Product, result.Version, result.EOLDate)
+			if result.DaysUntilEOL != nil {
+				event.Message = fmt.Sprintf("%s %s reaches its end of life in %d days on %s", result.Product, result.Version, *result.DaysUntilEOL, result.EOLDate)
+			}
+		default:
+			continue
+		}
+		if result.RecommendedUpgrade != "" {
+			event.Message += ", upgrade to " + result.RecommendedUpgrade
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// newEvent returns an event of a cycle with its status and EOL date at a point in time
+func newEvent(name string, product Product, at time.Time, warnDays int) Event {
+	annotation := product.AnnotationWithin(at, warnDays)
+	event := Event{
+		Time:      at,
+		Product:   name,
+		Cycle:     product.Cycle,
+		Status:    annotation.Status,
+		DaysToEOL: annotation.DaysToEOL,
+		Link:      product.Link,
+	}
+	if eolDate := dateValue(product.EOL); !eolDate.IsZero() {
+		event.EOLDate = eolDate.Format("2006-01-02")
+	}
+	return event
+}
@@ -0,0 +1,95 @@
+package eoldate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// Predicate reports whether a release cycle should be kept by Products.Filter
+type Predicate func(p Product) bool
+
+// Filter returns the release cycles matching all predicates
+func (p Products) Filter(predicates ...Predicate) Products {
+	filtered := make(Products, 0, len(p))
+	for _, product := range p {
+		keep := true
+		for _, predicate := range predicates {
+			if !predicate(product) {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			filtered = append(filtered, product)
+		}
+	}
+	return filtered
+}
+
+// SupportedAt keeps cycles that have not reached their end of life at the given time
+func SupportedAt(at time.Time) Predicate {
+	return func(p Product) bool {
+		return !p.IsEOL(at)
+	}
+}
+
+// EOLAt keeps cycles that have reached their end of life at the given time
+func EOLAt(at time.Time) Predicate {
+	return func(p Product) bool {
+		return p.IsEOL(at)
+	}
+}
+
+// LTSAt keeps long term support cycles, including cycles whose LTS phase has started at the given time
+func LTSAt(at time.Time) Predicate {
+	return func(p Product) bool {
+		return p.IsLTS(at)
+	}
+}
+
+// ReleasedSince keeps cycles released on or after the given date
+func ReleasedSince(since time.Time) Predicate {
+	return func(p Product) bool {
+		releaseDate, err := ParseDate(p.ReleaseDate)
+		return err == nil && !releaseDate.Before(since)
+	}
+}
+
+// CycleConstraint keeps cycles matching a semantic version constraint such as ">=3.8".
+// Cycles that are not semantic versions never match
+func CycleConstraint(constraint string) (Predicate, error) {
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return nil, fmt.Errorf("%w: constraint %s: %w", ErrInvalidVersion, constraint, err)
+	}
+	return func(p Product) bool {
+		version, err := semver.NewVersion(p.Cycle)
+		return err == nil && c.Check(version)
+	}, nil
+}
+
+// IsLTS reports whether the cycle is a long term support release at the given time.
+// The API either marks LTS cycles with true or with the date their LTS phase starts
+func (p *Product) IsLTS(at time.Time) bool {
+	switch lts := p.LTS.(type) {
+	case bool:
+		return lts
+	case string:
+		ltsDate, err := ParseDate(lts)
+		return err == nil && !at.Before(ltsDate)
+	default:
+		return false
+	}
+}
+
+// ParseDate parses the date formats used by the API: 2006-01-02, 2006-01 and 2006
+func ParseDate(date string) (time.Time, error) {
+	for _, format := range []string{"2006-01-02", "2006-01", "2006"} {
+		if t, err := time.Parse(format, date); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: %s", ErrInvalidDate, date)
+}
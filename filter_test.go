@@ -0,0 +1,51 @@
+package eoldate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProducts_Filter(t *testing.T) {
+	at := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	products := Products{
+		{Cycle: "3.12", ReleaseDate: "2023-10-02", EOL: "2028-10-31", LTS: false},
+		{Cycle: "3.8", ReleaseDate: "2019-10-14", EOL: "2024-10-07", LTS: "2020-01-01"},
+		{Cycle: "3.7", ReleaseDate: "2018-06-27", EOL: "2023-06-27", LTS: true},
+		{Cycle: "2.7", ReleaseDate: "2010-07-03", EOL: true},
+		{Cycle: "bullseye", ReleaseDate: "2021-08-14", EOL: false},
+	}
+	cycle38, err := CycleConstraint(">=3.8")
+	if err != nil {
+		t.Fatalf("CycleConstraint() error = %v", err)
+	}
+	tests := []struct {
+		name       string
+		predicates []Predicate
+		want       []string
+	}{
+		{name: "no predicates", predicates: nil, want: []string{"3.12", "3.8", "3.7", "2.7", "bullseye"}},
+		{name: "supported", predicates: []Predicate{SupportedAt(at)}, want: []string{"3.12", "3.8", "bullseye"}},
+		{name: "eol", predicates: []Predicate{EOLAt(at)}, want: []string{"3.7", "2.7"}},
+		{name: "lts", predicates: []Predicate{LTSAt(at)}, want: []string{"3.8", "3.7"}},
+		{name: "since 2020", predicates: []Predicate{ReleasedSince(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))}, want: []string{"3.12", "bullseye"}},
+		{name: "cycle constraint", predicates: []Predicate{cycle38}, want: []string{"3.12", "3.8"}},
+		{name: "combined", predicates: []Predicate{cycle38, LTSAt(at)}, want: []string{"3.8"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := products.Filter(tt.predicates...)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Filter() got %d cycles, want %v", len(got), tt.want)
+			}
+			for i := range got {
+				if got[i].Cycle != tt.want[i] {
+					t.Errorf("Filter()[%d] = %s, want %s", i, got[i].Cycle, tt.want[i])
+				}
+			}
+		})
+	}
+
+	if _, err = CycleConstraint("not a constraint"); err == nil {
+		t.Errorf("CycleConstraint() expected an error for an invalid constraint")
+	}
+}
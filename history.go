@@ -0,0 +1,94 @@
+package eoldate
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// historyDirName is the cache subdirectory holding product snapshots, kept when the cache is cleared
+const historyDirName = "history"
+
+// Snapshot is a copy of a product's data as fetched from the API on a date
+type Snapshot struct {
+	Product string    `json:"product"`
+	Date    time.Time `json:"date"`
+	Path    string    `json:"path"`
+}
+
+// HistoryDir returns the directory holding product snapshots, a history subdirectory of the cache directory
+func (c *Client) HistoryDir() (string, error) {
+	cacheDir, err := c.CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, historyDirName), nil
+}
+
+// Snapshots returns the recorded snapshots of a product, oldest first.
+// A snapshot is recorded whenever the product is fetched from the API and its data changed since the previous snapshot
+func (c *Client) Snapshots(product string) ([]Snapshot, error) {
+	historyDir, err := c.HistoryDir()
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(historyDir, product)
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, &CacheError{Op: "list", Path: dir, Err: err}
+	}
+	var snapshots []Snapshot
+	for _, file := range files {
+		date, err := time.Parse("2006-01-02", strings.TrimSuffix(filepath.Base(file), ".json"))
+		if err != nil {
+			continue
+		}
+		snapshots = append(snapshots, Snapshot{Product: product, Date: date, Path: file})
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Date.Before(snapshots[j].Date) })
+	return snapshots, nil
+}
+
+// Products reads the cycles of a snapshot
+func (s Snapshot) Products() (Products, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, &CacheError{Op: "read", Path: s.Path, Err: err}
+	}
+	var products Products
+	if err = json.Unmarshal(data, &products); err != nil {
+		return nil, &CacheError{Op: "decode", Path: s.Path, Err: err}
+	}
+	return products, nil
+}
+
+// writeSnapshot records the data of a product fetched at a time unless it matches the latest snapshot
+func (c *Client) writeSnapshot(product string, data []byte, at time.Time) error {
+	snapshots, err := c.Snapshots(product)
+	if err != nil {
+		return err
+	}
+	if len(snapshots) > 0 {
+		latest, err := os.ReadFile(snapshots[len(snapshots)-1].Path)
+		if err == nil && bytes.Equal(latest, data) {
+			return nil
+		}
+	}
+	historyDir, err := c.HistoryDir()
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(historyDir, product)
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return &CacheError{Op: "mkdir", Path: dir, Err: err}
+	}
+	file := filepath.Join(dir, at.Format("2006-01-02")+".json")
+	if err = os.WriteFile(file, data, 0600); err != nil {
+		return &CacheError{Op: "write", Path: file, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package eoldate
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_Snapshots(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/all.json":
+			_, _ = w.Write([]byte(`["php"]`))
+		case "/php.json":
+			_, _ = w.Write([]byte(`[{"cycle":"8.3","eol":"2027-12-31","latest":"8.3.12"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	older := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := c.writeSnapshot("php", []byte(`[{"cycle":"8.3","eol":"2026-11-23","latest":"8.3.0"}]`), older); err != nil {
+		t.Fatalf("writeSnapshot() error = %v", err)
+	}
+	// unchanged data is not recorded again
+	if err := c.writeSnapshot("php", []byte(`[{"cycle":"8.3","eol":"2026-11-23","latest":"8.3.0"}]`), older.AddDate(0, 0, 1)); err != nil {
+		t.Fatalf("writeSnapshot() error = %v", err)
+	}
+	if _, err := c.GetProduct("php"); err != nil {
+		t.Fatalf("GetProduct() error = %v", err)
+	}
+
+	snapshots, err := c.Snapshots("php")
+	if err != nil {
+		t.Fatalf("Snapshots() error = %v", err)
+	}
+	if len(snapshots) != 2 || !snapshots[0].Date.Equal(older) {
+		t.Fatalf("Snapshots() = %+v, want the 2024-01-01 snapshot and the fetched one", snapshots)
+	}
+	old, err := snapshots[0].Products()
+	if err != nil {
+		t.Fatalf("Snapshot.Products() error = %v", err)
+	}
+	current, err := snapshots[1].Products()
+	if err != nil {
+		t.Fatalf("Snapshot.Products() error = %v", err)
+	}
+	if changes := DiffProducts(old, current); len(changes) != 2 {
+		t.Errorf("DiffProducts() = %+v, want latest and eol changes", changes)
+	}
+
+	if err = c.ClearCache(); err != nil {
+		t.Fatalf("ClearCache() error = %v", err)
+	}
+	if snapshots, _ = c.Snapshots("php"); len(snapshots) != 2 {
+		t.Errorf("ClearCache() removed snapshots, %d left", len(snapshots))
+	}
+}
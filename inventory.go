@@ -0,0 +1,106 @@
+package eoldate
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// supported inventory formats
+const (
+	InventoryCSV  = "csv"
+	InventoryJSON = "json"
+	InventoryYAML = "yaml"
+)
+
+// ReadInventoryFile reads product/version pairs from a csv, json or yaml file, or from stdin when path is "-"
+func ReadInventoryFile(path, format string) ([]InventoryItem, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, LogError(err)
+	}
+	if format == "" {
+		format = DetectInventoryFormat(path, data)
+	}
+	return ParseInventory(data, format)
+}
+
+// DetectInventoryFormat guesses the inventory format from the file extension, falling back to the content
+func DetectInventoryFormat(path string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return InventoryJSON
+	case ".yaml", ".yml":
+		return InventoryYAML
+	case ".csv":
+		return InventoryCSV
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("[")), bytes.HasPrefix(trimmed, []byte("{")):
+		return InventoryJSON
+	case bytes.HasPrefix(trimmed, []byte("-")):
+		return InventoryYAML
+	default:
+		return InventoryCSV
+	}
+}
+
+// ParseInventory parses product/version pairs in the given format.
+// CSV input may start with a product,version header row
+func ParseInventory(data []byte, format string) ([]InventoryItem, error) {
+	var items []InventoryItem
+	switch format {
+	case InventoryJSON:
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, fmt.Errorf("parsing json inventory: %w", err)
+		}
+	case InventoryYAML:
+		if err := yaml.Unmarshal(data, &items); err != nil {
+			return nil, fmt.Errorf("parsing yaml inventory: %w", err)
+		}
+	case InventoryCSV:
+		r := csv.NewReader(bytes.NewReader(data))
+		r.Comment = '#'
+		r.TrimLeadingSpace = true
+		r.FieldsPerRecord = -1
+		records, err := r.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("parsing csv inventory: %w", err)
+		}
+		for i, record := range records {
+			if len(record) < 2 {
+				return nil, fmt.Errorf("parsing csv inventory: line %d: expected product,version", i+1)
+			}
+			if i == 0 && strings.EqualFold(record[0], "product") && strings.EqualFold(record[1], "version") {
+				continue
+			}
+			items = append(items, InventoryItem{Product: record[0], Version: record[1]})
+		}
+	default:
+		return nil, fmt.Errorf("unsupported inventory format: %s", format)
+	}
+
+	for i := range items {
+		items[i].Product = strings.TrimSpace(items[i].Product)
+		items[i].Version = strings.TrimSpace(items[i].Version)
+		if items[i].Product == "" || items[i].Version == "" {
+			return nil, fmt.Errorf("inventory entry %d: product and version are required", i+1)
+		}
+	}
+	return items, nil
+}
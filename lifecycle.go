@@ -0,0 +1,98 @@
+package eoldate
+
+import "time"
+
+// Phase is a support phase of a release cycle
+type Phase string
+
+// Phases of a release cycle in the order they follow each other
+const (
+	PhaseActive   Phase = "active support"
+	PhaseSecurity Phase = "security support"
+	PhaseExtended Phase = "extended support"
+)
+
+// Lifecycle holds the release date and phase boundaries of a cycle, a zero date means the phase has no announced end
+type Lifecycle struct {
+	Cycle           string
+	Release         time.Time
+	SupportEnd      time.Time
+	EOL             time.Time
+	ExtendedSupport time.Time
+}
+
+// LifecyclePhase is a support phase of a cycle between two dates
+type LifecyclePhase struct {
+	Phase Phase
+	Start time.Time
+	End   time.Time
+}
+
+// Lifecycle returns the phase boundaries of a cycle, or false when it has no release date.
+// A cycle that reached EOL on an unknown date ends with its active support
+func (p Product) Lifecycle() (Lifecycle, bool) {
+	release, err := ParseDate(p.ReleaseDate)
+	if err != nil {
+		return Lifecycle{}, false
+	}
+	lc := Lifecycle{
+		Cycle:           p.Cycle,
+		Release:         release,
+		SupportEnd:      dateValue(p.Support),
+		EOL:             dateValue(p.EOL),
+		ExtendedSupport: dateValue(p.ExtendedSupport),
+	}
+	if eol, ok := p.EOL.(bool); ok && eol {
+		lc.EOL = lc.SupportEnd
+		if lc.EOL.Before(release) {
+			lc.EOL = release
+		}
+	}
+	return lc, true
+}
+
+// Dates returns the known dates of the cycle in order of its phases, leaving out unannounced ends
+func (lc Lifecycle) Dates() []time.Time {
+	var dates []time.Time
+	for _, t := range []time.Time{lc.Release, lc.SupportEnd, lc.EOL, lc.ExtendedSupport} {
+		if !t.IsZero() {
+			dates = append(dates, t)
+		}
+	}
+	return dates
+}
+
+// Phases returns the consecutive support phases of the cycle, ending phases without an announced end at until.
+// Active support always starts at the release and ends no later than EOL,
+// security and extended support are only returned when they last beyond the previous phase
+func (lc Lifecycle) Phases(until time.Time) []LifecyclePhase {
+	eol := lc.EOL
+	if eol.IsZero() {
+		eol = until
+	}
+	supportEnd := lc.SupportEnd
+	if supportEnd.IsZero() || supportEnd.After(eol) {
+		supportEnd = eol
+	}
+	phases := []LifecyclePhase{{Phase: PhaseActive, Start: lc.Release, End: supportEnd}}
+	if eol.After(supportEnd) {
+		phases = append(phases, LifecyclePhase{Phase: PhaseSecurity, Start: supportEnd, End: eol})
+	}
+	if lc.ExtendedSupport.After(eol) {
+		phases = append(phases, LifecyclePhase{Phase: PhaseExtended, Start: eol, End: lc.ExtendedSupport})
+	}
+	return phases
+}
+
+// dateValue returns the date of a support, eol or extendedSupport value, or the zero time for booleans and invalid dates
+func dateValue(value interface{}) time.Time {
+	s, ok := value.(string)
+	if !ok {
+		return time.Time{}
+	}
+	date, err := ParseDate(s)
+	if err != nil {
+		return time.Time{}
+	}
+	return date
+}
@@ -0,0 +1,77 @@
+package eoldate
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestProduct_Lifecycle(t *testing.T) {
+	date := func(s string) time.Time {
+		d, _ := ParseDate(s)
+		return d
+	}
+	until := date("2035-01-01")
+	tests := []struct {
+		name    string
+		product Product
+		wantOK  bool
+		want    []LifecyclePhase
+	}{
+		{
+			name:    "all phases",
+			product: Product{Cycle: "22.04", ReleaseDate: "2022-04-21", Support: "2024-09-30", EOL: "2027-06-01", ExtendedSupport: "2032-04-09"},
+			wantOK:  true,
+			want: []LifecyclePhase{
+				{Phase: PhaseActive, Start: date("2022-04-21"), End: date("2024-09-30")},
+				{Phase: PhaseSecurity, Start: date("2024-09-30"), End: date("2027-06-01")},
+				{Phase: PhaseExtended, Start: date("2027-06-01"), End: date("2032-04-09")},
+			},
+		},
+		{
+			name:    "open ended",
+			product: Product{Cycle: "24.04", ReleaseDate: "2024-04-25", EOL: false},
+			wantOK:  true,
+			want:    []LifecyclePhase{{Phase: PhaseActive, Start: date("2024-04-25"), End: until}},
+		},
+		{
+			name:    "EOL without a date ends with active support",
+			product: Product{Cycle: "5.6", ReleaseDate: "2014-08-28", Support: "2017-01-19", EOL: true},
+			wantOK:  true,
+			want:    []LifecyclePhase{{Phase: PhaseActive, Start: date("2014-08-28"), End: date("2017-01-19")}},
+		},
+		{
+			name:    "EOL without any date ends at the release",
+			product: Product{Cycle: "1.0", ReleaseDate: "2014-08-28", EOL: true},
+			wantOK:  true,
+			want:    []LifecyclePhase{{Phase: PhaseActive, Start: date("2014-08-28"), End: date("2014-08-28")}},
+		},
+		{
+			name:    "support and extended support are clamped to EOL",
+			product: Product{Cycle: "8", ReleaseDate: "2020-01-01", Support: "2030-01-01", EOL: "2025-01-01", ExtendedSupport: "2024-01-01"},
+			wantOK:  true,
+			want:    []LifecyclePhase{{Phase: PhaseActive, Start: date("2020-01-01"), End: date("2025-01-01")}},
+		},
+		{
+			name:    "no release date",
+			product: Product{Cycle: "next", EOL: "2030-01-01"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc, ok := tt.product.Lifecycle()
+			if ok != tt.wantOK {
+				t.Fatalf("Lifecycle() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if lc.Cycle != tt.product.Cycle {
+				t.Errorf("Lifecycle() cycle = %q, want %q", lc.Cycle, tt.product.Cycle)
+			}
+			if got := lc.Phases(until); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Phases() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
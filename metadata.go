@@ -0,0 +1,140 @@
+package eoldate
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// productsInfoCacheName is the cache entry holding the v1 product metadata
+const productsInfoCacheName = "products-info"
+
+// ProductInfo is the metadata of a product listed by the v1 endoflife.date API
+type ProductInfo struct {
+	Name     string   `json:"name"`
+	Label    string   `json:"label,omitempty"`
+	Category string   `json:"category,omitempty"`
+	Aliases  []string `json:"aliases,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	URI      string   `json:"uri,omitempty"`
+}
+
+// productsInfoResponse is the envelope of the v1 products endpoint
+type productsInfoResponse struct {
+	Result []ProductInfo `json:"result"`
+}
+
+// GetProductsInfo fetches the metadata of all products such as their label and category
+func (c *Client) GetProductsInfo() ([]ProductInfo, error) {
+	if _, err := c.CacheTechnologies(); err != nil {
+		return nil, err
+	}
+	data, err := c.readCache(productsInfoCacheName)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		if data, err = c.Get("v1/products"); err != nil {
+			return nil, err
+		}
+		if err = c.writeCache(productsInfoCacheName, data); err != nil {
+			return nil, err
+		}
+	}
+
+	var response productsInfoResponse
+	if err = json.Unmarshal(data, &response); err != nil {
+		return nil, err
+	}
+	sort.Slice(response.Result, func(i, j int) bool { return response.Result[i].Name < response.Result[j].Name })
+	return response.Result, nil
+}
+
+// Matches reports whether the product name, label or one of its aliases contains filter, ignoring case
+func (i ProductInfo) Matches(filter string) bool {
+	filter = strings.ToLower(filter)
+	if strings.Contains(strings.ToLower(i.Name), filter) || strings.Contains(strings.ToLower(i.Label), filter) {
+		return true
+	}
+	for _, alias := range i.Aliases {
+		if strings.Contains(strings.ToLower(alias), filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetProducts fetches many products concurrently.
+// It returns the products and the errors of products that could not be fetched, keyed by name
+func (c *Client) GetProducts(names []string, concurrency int) (map[string]Products, map[string]error) {
+	products := make(map[string]Products, len(names))
+	errs := make(map[string]error)
+	// populate the technologies cache up front so workers don't race on writing it
+	if _, err := c.CacheTechnologies(); err != nil {
+		for _, name := range names {
+			errs[name] = err
+		}
+		return products, errs
+	}
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for _, name := range names {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(name string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			data, err := c.GetProduct(name)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[name] = err
+				return
+			}
+			products[name] = data
+		}(name)
+	}
+	wg.Wait()
+	return products, errs
+}
+
+// LatestCycle returns the most recently released cycle, or nil when there are no cycles
+func (p Products) LatestCycle() *Product {
+	var latest *Product
+	for i := range p {
+		if latest == nil || p[i].ReleaseDate > latest.ReleaseDate {
+			latest = &p[i]
+		}
+	}
+	return latest
+}
+
+// IsEOL reports whether the cycle has reached its end of life at the given time
+func (p *Product) IsEOL(at time.Time) bool {
+	if eol, ok := p.EOL.(bool); ok {
+		return eol
+	}
+	eolDate, err := p.GetEOLDate()
+	if err != nil {
+		return false
+	}
+	return !at.Before(eolDate)
+}
+
+// SupportedCycles returns the cycles that have not reached their end of life at the given time
+func (p Products) SupportedCycles(at time.Time) Products {
+	var supported Products
+	for _, product := range p {
+		if !product.IsEOL(at) {
+			supported = append(supported, product)
+		}
+	}
+	return supported
+}
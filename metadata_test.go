@@ -0,0 +1,47 @@
+package eoldate
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_GetProductsInfo(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/all.json":
+			_, _ = w.Write([]byte(`["php","nodejs"]`))
+		case "/v1/products":
+			_, _ = w.Write([]byte(`{"total":2,"result":[{"name":"php","label":"PHP","category":"lang"},{"name":"nodejs","label":"Node.js","category":"framework","aliases":["node"]}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	infos, err := c.GetProductsInfo()
+	if err != nil {
+		t.Fatalf("GetProductsInfo() error = %v", err)
+	}
+	if len(infos) != 2 || infos[0].Name != "nodejs" || infos[1].Category != "lang" {
+		t.Errorf("GetProductsInfo() = %+v, want nodejs and php sorted by name", infos)
+	}
+	if !infos[0].Matches("NODE") || infos[1].Matches("node") {
+		t.Errorf("ProductInfo.Matches() did not match on name and aliases")
+	}
+}
+
+func TestProducts_SupportedCycles(t *testing.T) {
+	at := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	products := Products{
+		{Cycle: "3", ReleaseDate: "2024-01-10", EOL: false},
+		{Cycle: "2", ReleaseDate: "2022-01-10", EOL: "2024-06-02"},
+		{Cycle: "1", ReleaseDate: "2020-01-10", EOL: "2024-06-01"},
+		{Cycle: "0", ReleaseDate: "2018-01-10", EOL: true},
+	}
+	if got := len(products.SupportedCycles(at)); got != 2 {
+		t.Errorf("SupportedCycles() got %d cycles, want 2", got)
+	}
+	if got := products.LatestCycle(); got == nil || got.Cycle != "3" {
+		t.Errorf("LatestCycle() = %v, want cycle 3", got)
+	}
+}
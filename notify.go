@@ -0,0 +1,194 @@
+package eoldate
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Defaults of WebhookOptions
+const (
+	DefaultWebhookRetries = 3
+	DefaultWebhookBackoff = time.Second
+)
+
+// WebhookSignatureHeader holds the hex encoded HMAC-SHA256 of the request body keyed with WebhookOptions.Secret,
+// prefixed with sha256=
+const WebhookSignatureHeader = "X-Eoldate-Signature"
+
+// Notifier delivers events, e.g. to a chat channel or an incident system
+type Notifier interface {
+	Notify(events []Event) error
+}
+
+// PayloadFunc builds the request body sent for a batch of events
+type PayloadFunc func(events []Event) ([]byte, error)
+
+// WebhookPayload is the default JSON body posted by a WebhookNotifier and the data passed to payload templates
+type WebhookPayload struct {
+	Source  string    `json:"source"`
+	Version string    `json:"version"`
+	Time    time.Time `json:"time"`
+	Events  []Event   `json:"events"`
+}
+
+// WebhookOptions configures a WebhookNotifier
+type WebhookOptions struct {
+	// URL receives a POST request for every batch of events
+	URL string
+	// Secret signs request bodies in the WebhookSignatureHeader header when set
+	Secret string
+	// Template is a text/template rendering the body from a WebhookPayload, see TemplatePayload
+	Template string
+	// Payload builds the body instead of Template, e.g. SlackPayload or TeamsPayload, defaults to JSONPayload
+	Payload PayloadFunc
+	// ContentType of the body, defaults to application/json
+	ContentType string
+	// Headers are added to every request
+	Headers map[string]string
+	// Retries is the number of times a request is retried after network errors, 429 and 5xx responses,
+	// defaults to DefaultWebhookRetries. Use a negative number to disable retries
+	Retries int
+	// Backoff is the wait before the first retry and doubles after each retry, defaults to DefaultWebhookBackoff
+	Backoff time.Duration
+	// HTTPClient sends the requests, defaults to a client with a 30 second timeout
+	HTTPClient *http.Client
+}
+
+// WebhookNotifier posts events as JSON to a URL
+type WebhookNotifier struct {
+	opts WebhookOptions
+}
+
+// NewWebhookNotifier validates the options and parses the payload template when there is no Payload function
+func NewWebhookNotifier(opts WebhookOptions) (*WebhookNotifier, error) {
+	u, err := url.Parse(opts.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid webhook URL %q", opts.URL)
+	}
+	if opts.ContentType == "" {
+		opts.ContentType = "application/json"
+	}
+	if opts.Retries == 0 {
+		opts.Retries = DefaultWebhookRetries
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = DefaultWebhookBackoff
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	if opts.Payload == nil && opts.Template != "" {
+		if opts.Payload, err = TemplatePayload(opts.Template); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Payload == nil {
+		opts.Payload = JSONPayload
+	}
+	return &WebhookNotifier{opts: opts}, nil
+}
+
+// Payload returns the request body sent for events
+func (n *WebhookNotifier) Payload(events []Event) ([]byte, error) {
+	return n.opts.Payload(events)
+}
+
+// JSONPayload is the default PayloadFunc, encoding a WebhookPayload as JSON
+func JSONPayload(events []Event) ([]byte, error) {
+	return json.Marshal(newWebhookPayload(events))
+}
+
+// TemplatePayload returns a PayloadFunc rendering a WebhookPayload with a text/template.
+// The json function encodes a value as JSON and join joins strings
+func TemplatePayload(text string) (PayloadFunc, error) {
+	tmpl, err := template.New("webhook").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+		"join": strings.Join,
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook template: %w", err)
+	}
+	return func(events []Event) ([]byte, error) {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, newWebhookPayload(events)); err != nil {
+			return nil, fmt.Errorf("rendering webhook template: %w", err)
+		}
+		return buf.Bytes(), nil
+	}, nil
+}
+
+// newWebhookPayload wraps events in a WebhookPayload sent now, encoding no events as an empty list instead of null
+func newWebhookPayload(events []Event) WebhookPayload {
+	if events == nil {
+		events = []Event{}
+	}
+	return WebhookPayload{Source: "eoldate", Version: CurrentVersion, Time: time.Now().UTC(), Events: events}
+}
+
+// Notify posts the events in a single request, retrying temporary failures. Nothing is sent without events
+func (n *WebhookNotifier) Notify(events []Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	body, err := n.Payload(events)
+	if err != nil {
+		return err
+	}
+	backoff := n.opts.Backoff
+	for attempt := 0; ; attempt++ {
+		err = n.post(body)
+		var httpErr *HTTPError
+		if err == nil || attempt >= n.opts.Retries || (errors.As(err, &httpErr) && !httpErr.Temporary()) {
+			return err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// post sends one request with the body
+func (n *WebhookNotifier) post(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, n.opts.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", n.opts.ContentType)
+	req.Header.Set("User-Agent", "eoldate/"+CurrentVersion)
+	for key, value := range n.opts.Headers {
+		req.Header.Set(key, value)
+	}
+	if n.opts.Secret != "" {
+		req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(n.opts.Secret, body))
+	}
+	resp, err := n.opts.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to %s: %w", n.opts.URL, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &HTTPError{URL: n.opts.URL, StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+	return nil
+}
+
+// SignWebhookPayload returns the WebhookSignatureHeader value of a body, so receivers can verify requests
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
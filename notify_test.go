@@ -0,0 +1,155 @@
+package eoldate
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	events := []Event{{Kind: EventEOL, Product: "php", Cycle: "7.4", Version: "7.4.33", Status: StatusEOL, Message: "php 7.4.33 reached its end of life"}}
+	tests := []struct {
+		name     string
+		opts     WebhookOptions
+		statuses []int
+		wantErr  bool
+		wantHits int32
+		check    func(t *testing.T, r *http.Request, body []byte)
+	}{
+		{
+			name:     "signed default payload",
+			opts:     WebhookOptions{Secret: "s3cret", Headers: map[string]string{"Authorization": "Bearer token"}},
+			statuses: []int{http.StatusOK},
+			wantHits: 1,
+			check: func(t *testing.T, r *http.Request, body []byte) {
+				if got, want := r.Header.Get(WebhookSignatureHeader), SignWebhookPayload("s3cret", body); got != want {
+					t.Errorf("signature = %q, want %q", got, want)
+				}
+				if r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("Content-Type") != "application/json" {
+					t.Errorf("headers = %v, want Authorization and Content-Type", r.Header)
+				}
+				var payload WebhookPayload
+				if err := json.Unmarshal(body, &payload); err != nil || len(payload.Events) != 1 || payload.Events[0].Cycle != "7.4" {
+					t.Errorf("payload = %s, %v, want the event", body, err)
+				}
+			},
+		},
+		{
+			name:     "templated payload",
+			opts:     WebhookOptions{Template: `{"text":{{range .Events}}{{json .Message}}{{end}}}`},
+			statuses: []int{http.StatusOK},
+			wantHits: 1,
+			check: func(t *testing.T, r *http.Request, body []byte) {
+				if got := string(body); got != `{"text":"php 7.4.33 reached its end of life"}` {
+					t.Errorf("body = %s", got)
+				}
+				if r.Header.Get(WebhookSignatureHeader) != "" {
+					t.Errorf("unsigned request has a signature header")
+				}
+			},
+		},
+		{
+			name:     "retries temporary failures",
+			statuses: []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusNoContent},
+			wantHits: 3,
+		},
+		{
+			name:     "gives up after retries",
+			opts:     WebhookOptions{Retries: 1},
+			statuses: []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusOK},
+			wantErr:  true,
+			wantHits: 2,
+		},
+		{
+			name:     "does not retry client errors",
+			statuses: []int{http.StatusBadRequest, http.StatusOK},
+			wantErr:  true,
+			wantHits: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hit := atomic.AddInt32(&hits, 1)
+				body, _ := io.ReadAll(r.Body)
+				if tt.check != nil {
+					tt.check(t, r, body)
+				}
+				w.WriteHeader(tt.statuses[min(int(hit), len(tt.statuses))-1])
+			}))
+			defer server.Close()
+
+			tt.opts.URL = server.URL
+			tt.opts.Backoff = time.Millisecond
+			n, err := NewWebhookNotifier(tt.opts)
+			if err != nil {
+				t.Fatalf("NewWebhookNotifier() error = %v", err)
+			}
+			err = n.Notify(events)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Notify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var httpErr *HTTPError
+			if tt.wantErr && !errors.As(err, &httpErr) {
+				t.Errorf("Notify() error = %v, want an HTTPError", err)
+			}
+			if got := atomic.LoadInt32(&hits); got != tt.wantHits {
+				t.Errorf("Notify() sent %d requests, want %d", got, tt.wantHits)
+			}
+		})
+	}
+}
+
+func TestNewWebhookNotifier_Invalid(t *testing.T) {
+	for _, opts := range []WebhookOptions{
+		{URL: "ftp://example.com"},
+		{URL: "not a url"},
+		{URL: "https://example.com", Template: "{{.Events"},
+	} {
+		if _, err := NewWebhookNotifier(opts); err == nil {
+			t.Errorf("NewWebhookNotifier(%+v) error = nil, want an error", opts)
+		}
+	}
+}
+
+func TestJSONPayload_NoEvents(t *testing.T) {
+	for _, events := range [][]Event{nil, {}} {
+		body, err := JSONPayload(events)
+		if err != nil {
+			t.Fatalf("JSONPayload(%#v) error = %v", events, err)
+		}
+		var payload map[string]interface{}
+		if err = json.Unmarshal(body, &payload); err != nil {
+			t.Fatal(err)
+		}
+		if list, ok := payload["events"].([]interface{}); !ok || len(list) != 0 {
+			t.Errorf("JSONPayload(%#v) events = %#v, want an empty list", events, payload["events"])
+		}
+	}
+}
+
+func TestCheckEvents(t *testing.T) {
+	days := 30
+	results := []CheckResult{
+		{Product: "php", Version: "7.4.33", Cycle: "7.4", Status: StatusEOL, EOLDate: "2022-11-28", RecommendedUpgrade: "8.3.12"},
+		{Product: "nodejs", Version: "18.20.0", Cycle: "18", Status: StatusApproachingEOL, EOLDate: "2025-04-30", DaysUntilEOL: &days},
+		{Product: "go", Version: "1.23.1", Cycle: "1.23", Status: StatusSupported},
+		{Product: "nope", Version: "1", Status: StatusUnknown, Error: "product not found"},
+	}
+	events := CheckEvents(results, time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC))
+	if len(events) != 2 {
+		t.Fatalf("CheckEvents() = %+v, want 2 events", events)
+	}
+	if events[0].Kind != EventEOL || events[0].Message != "php 7.4.33 reached its end of life on 2022-11-28, upgrade to 8.3.12" {
+		t.Errorf("CheckEvents() EOL event = %+v", events[0])
+	}
+	if events[1].Kind != EventApproachingEOL || events[1].Message != "nodejs 18.20.0 reaches its end of life in 30 days on 2025-04-30" {
+		t.Errorf("CheckEvents() approaching event = %+v", events[1])
+	}
+}
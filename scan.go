@@ -0,0 +1,201 @@
+package eoldate
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// dockerImageProducts maps docker image names to endoflife.date product names
+var dockerImageProducts = map[string]string{
+	"alpine":        "alpine",
+	"debian":        "debian",
+	"elasticsearch": "elasticsearch",
+	"golang":        "go",
+	"mariadb":       "mariadb",
+	"mongo":         "mongodb",
+	"mysql":         "mysql",
+	"nginx":         "nginx",
+	"node":          "nodejs",
+	"php":           "php",
+	"postgres":      "postgresql",
+	"python":        "python",
+	"rabbitmq":      "rabbitmq",
+	"redis":         "redis",
+	"ruby":          "ruby",
+	"tomcat":        "tomcat",
+	"ubuntu":        "ubuntu",
+}
+
+// toolVersionsProducts maps asdf .tool-versions plugin names to endoflife.date product names
+var toolVersionsProducts = map[string]string{
+	"golang":    "go",
+	"nodejs":    "nodejs",
+	"php":       "php",
+	"postgres":  "postgresql",
+	"python":    "python",
+	"ruby":      "ruby",
+	"terraform": "terraform",
+	"elixir":    "elixir",
+	"erlang":    "erlang",
+}
+
+// versionFileProducts maps single line version files to endoflife.date product names
+var versionFileProducts = map[string]string{
+	".go-version":        "go",
+	".node-version":      "nodejs",
+	".nvmrc":             "nodejs",
+	".python-version":    "python",
+	".ruby-version":      "ruby",
+	".terraform-version": "terraform",
+}
+
+var (
+	leadingVersionRe  = regexp.MustCompile(`^v?(\d+(?:\.\d+){0,2})`)
+	anyVersionRe      = regexp.MustCompile(`(\d+(?:\.\d+){0,2})`)
+	dockerFromRe      = regexp.MustCompile(`(?i)^\s*FROM\s+(?:--\S+\s+)*(\S+)`)
+	goDirectiveRe     = regexp.MustCompile(`^go\s+(\d+(?:\.\d+){0,2})\s*$`)
+	packageJSONNodeRe = regexp.MustCompile(`"node"\s*:\s*"([^"]+)"`)
+	composerPHPRe     = regexp.MustCompile(`"php"\s*:\s*"([^"]+)"`)
+)
+
+// scanSkipDirs are directories never descended into by ScanDir
+var scanSkipDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"vendor":       true,
+}
+
+// ScanDir walks root and detects installed product versions from common version files such as
+// Dockerfiles, go.mod, package.json, composer.json, .tool-versions, .nvmrc and .python-version
+func ScanDir(root string) ([]InventoryItem, error) {
+	var items []InventoryItem
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && scanSkipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		found, err := ScanFile(path)
+		if err != nil {
+			return err
+		}
+		items = append(items, found...)
+		return nil
+	})
+	if err != nil {
+		return nil, LogError(err)
+	}
+	return items, nil
+}
+
+// ScanFile detects installed product versions in a single file.
+// Files that are not recognized return no items
+func ScanFile(path string) ([]InventoryItem, error) {
+	name := filepath.Base(path)
+	var detect func(line string) (string, string)
+	switch {
+	case versionFileProducts[name] != "":
+		product := versionFileProducts[name]
+		detect = func(line string) (string, string) {
+			line = strings.TrimPrefix(strings.TrimSpace(line), product+"-")
+			return product, leadingVersion(line)
+		}
+	case name == ".tool-versions":
+		detect = func(line string) (string, string) {
+			fields := strings.Fields(line)
+			if len(fields) < 2 || toolVersionsProducts[fields[0]] == "" {
+				return "", ""
+			}
+			return toolVersionsProducts[fields[0]], leadingVersion(fields[1])
+		}
+	case name == "go.mod":
+		detect = func(line string) (string, string) {
+			if m := goDirectiveRe.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+				return "go", m[1]
+			}
+			return "", ""
+		}
+	case name == "package.json":
+		detect = func(line string) (string, string) {
+			return constraintVersion(packageJSONNodeRe, "nodejs", line)
+		}
+	case name == "composer.json":
+		detect = func(line string) (string, string) {
+			return constraintVersion(composerPHPRe, "php", line)
+		}
+	case name == "Dockerfile", strings.HasPrefix(name, "Dockerfile."), strings.HasSuffix(name, ".dockerfile"):
+		detect = dockerfileVersion
+	default:
+		return nil, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var items []InventoryItem
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		product, version := detect(scanner.Text())
+		if product == "" || version == "" {
+			continue
+		}
+		items = append(items, InventoryItem{Product: product, Version: version, Source: path, Line: lineNumber})
+	}
+	return items, scanner.Err()
+}
+
+// dockerfileVersion detects the product and version of a FROM image:tag instruction
+func dockerfileVersion(line string) (string, string) {
+	m := dockerFromRe.FindStringSubmatch(line)
+	if m == nil {
+		return "", ""
+	}
+	image := m[1]
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	colon := strings.LastIndex(image, ":")
+	if colon < 0 || colon < strings.LastIndex(image, "/") {
+		return "", ""
+	}
+	name, tag := image[:colon], image[colon+1:]
+	name = name[strings.LastIndex(name, "/")+1:]
+	product, ok := dockerImageProducts[name]
+	if !ok {
+		return "", ""
+	}
+	return product, leadingVersion(tag)
+}
+
+// constraintVersion extracts the first version of a dependency constraint such as ">=18" or "^7.4|^8.0"
+func constraintVersion(re *regexp.Regexp, product, line string) (string, string) {
+	m := re.FindStringSubmatch(line)
+	if m == nil {
+		return "", ""
+	}
+	if v := anyVersionRe.FindString(m[1]); v != "" {
+		return product, v
+	}
+	return "", ""
+}
+
+// leadingVersion returns the numeric version at the start of s, e.g. 7.4 for 7.4-fpm-alpine
+func leadingVersion(s string) string {
+	if m := leadingVersionRe.FindStringSubmatch(strings.TrimSpace(s)); m != nil {
+		return m[1]
+	}
+	return ""
+}
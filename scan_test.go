@@ -0,0 +1,90 @@
+package eoldate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_dockerfileVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		line        string
+		wantProduct string
+		wantVersion string
+	}{
+		{name: "official image", line: "FROM php:7.4-fpm-alpine", wantProduct: "php", wantVersion: "7.4"},
+		{name: "platform flag and stage", line: "FROM --platform=linux/amd64 node:20.11 AS build", wantProduct: "nodejs", wantVersion: "20.11"},
+		{name: "registry prefix", line: "from docker.io/library/postgres:16.2", wantProduct: "postgresql", wantVersion: "16.2"},
+		{name: "registry with port", line: "FROM registry:5000/python", wantProduct: "", wantVersion: ""},
+		{name: "latest tag", line: "FROM golang:latest", wantProduct: "go", wantVersion: ""},
+		{name: "unknown image", line: "FROM scratch", wantProduct: "", wantVersion: ""},
+		{name: "not a FROM line", line: "RUN apt-get install php", wantProduct: "", wantVersion: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product, version := dockerfileVersion(tt.line)
+			if product != tt.wantProduct || version != tt.wantVersion {
+				t.Errorf("dockerfileVersion() = %v, %v, want %v, %v", product, version, tt.wantProduct, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestScanDir(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"Dockerfile":                "FROM python:3.8-slim\n",
+		".nvmrc":                    "v20.11.1\n",
+		"api/go.mod":                "module example.com/api\n\ngo 1.21.5\n",
+		"web/package.json":          "{\n  \"engines\": {\n    \"node\": \">=18\"\n  }\n}\n",
+		"node_modules/x/.nvmrc":     "16\n",
+		"api/.tool-versions":        "ruby 3.2.2\nunknown 1.0\n",
+		"README.md":                 "FROM php:8.3\n",
+		"docker/app.dockerfile":     "FROM ubuntu:22.04\n",
+		".python-version":           "3.12.1\n",
+		"legacy/.ruby-version":      "ruby-2.7.8\n",
+		"legacy/composer.json":      "{\n  \"require\": {\n    \"php\": \"^7.4|^8.0\"\n  }\n}\n",
+		"node_modules/y/Dockerfile": "FROM node:14\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	items, err := ScanDir(root)
+	if err != nil {
+		t.Fatalf("ScanDir() error = %v", err)
+	}
+	want := []string{
+		"python 3.8",
+		"nodejs 20.11.1",
+		"go 1.21.5",
+		"nodejs 18",
+		"ruby 3.2.2",
+		"ubuntu 22.04",
+		"python 3.12.1",
+		"ruby 2.7.8",
+		"php 7.4",
+	}
+	got := make(map[string]bool)
+	for _, item := range items {
+		if item.Line == 0 || item.Source == "" {
+			t.Errorf("ScanDir() item %+v has no source location", item)
+		}
+		got[item.Product+" "+item.Version] = true
+	}
+	if len(items) != len(want) {
+		t.Errorf("ScanDir() found %d items, want %d: %+v", len(items), len(want), items)
+	}
+	for _, w := range want {
+		if !got[w] {
+			t.Errorf("ScanDir() did not detect %s", w)
+		}
+	}
+}
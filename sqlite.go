@@ -0,0 +1,306 @@
+package eoldate
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// sqliteSchema creates the tables written by ExportSQLite.
+// Dates are stored as YYYY-MM-DD text so they compare and sort correctly in SQL
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS products (
+	name       TEXT PRIMARY KEY,
+	label      TEXT,
+	category   TEXT,
+	cycles     INTEGER NOT NULL,
+	hash       TEXT NOT NULL,
+	fetched_at TEXT NOT NULL,
+	updated_at TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS cycles (
+	product                  TEXT NOT NULL REFERENCES products(name),
+	cycle                    TEXT NOT NULL,
+	position                 INTEGER NOT NULL,
+	release_date             TEXT,
+	latest                   TEXT,
+	latest_release_date      TEXT,
+	link                     TEXT,
+	lts                      INTEGER,
+	lts_date                 TEXT,
+	support                  INTEGER,
+	support_date             TEXT,
+	eol                      INTEGER,
+	eol_date                 TEXT,
+	extended_support         INTEGER,
+	extended_support_date    TEXT,
+	status                   TEXT NOT NULL,
+	days_to_eol              INTEGER,
+	days_to_support_end      INTEGER,
+	PRIMARY KEY (product, cycle)
+);
+CREATE INDEX IF NOT EXISTS cycles_eol_date ON cycles(eol_date);
+CREATE TABLE IF NOT EXISTS cycle_fields (
+	product TEXT NOT NULL,
+	cycle   TEXT NOT NULL,
+	key     TEXT NOT NULL,
+	value   TEXT,
+	PRIMARY KEY (product, cycle, key),
+	FOREIGN KEY (product, cycle) REFERENCES cycles(product, cycle)
+);
+CREATE TABLE IF NOT EXISTS exports (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	started_at TEXT NOT NULL,
+	as_of      TEXT NOT NULL,
+	source     TEXT,
+	version    TEXT NOT NULL,
+	products   INTEGER NOT NULL,
+	updated    INTEGER NOT NULL,
+	failed     INTEGER NOT NULL
+);
+`
+
+// sqliteCycleColumns are the Product fields stored as columns of the cycles table,
+// every other field is stored in cycle_fields
+var sqliteCycleColumns = map[string]bool{
+	"cycle":             true,
+	"releaseDate":       true,
+	"latest":            true,
+	"latestReleaseDate": true,
+	"link":              true,
+	"lts":               true,
+	"support":           true,
+	"eol":               true,
+	"extendedSupport":   true,
+}
+
+// SQLiteOptions controls how ExportSQLite writes products
+type SQLiteOptions struct {
+	// At is the point in time statuses and days remaining are computed at, defaults to now
+	At time.Time
+	// WarnDays reports cycles reaching EOL within this many days as approaching EOL, nil defaults to DefaultWarnDays
+	WarnDays *int
+	// Info adds the label and category of products
+	Info []ProductInfo
+	// Source is recorded in the exports table, usually the API base URL
+	Source string
+	// Failed are the products that could not be fetched, counted in the exports table
+	Failed []string
+}
+
+// SQLiteExport summarizes a call to ExportSQLite
+type SQLiteExport struct {
+	// Products is the number of products written, Updated the number whose cycles changed since the previous export
+	Products int
+	Updated  int
+}
+
+// ExportSQLite writes products keyed by name to a SQLite database, creating the schema when needed.
+// The products table holds one row per product, cycles one row per cycle with normalized dates and status,
+// cycle_fields the remaining fields as key/value pairs and exports a row per call.
+//
+// Exports are incremental: cycles of a product are only rewritten when its data changed,
+// products that are not passed are kept and the status of every cycle is recomputed at opts.At.
+// The caller opens db with a SQLite driver
+func ExportSQLite(db *sql.DB, products map[string]Products, opts SQLiteOptions) (SQLiteExport, error) {
+	var export SQLiteExport
+	if opts.At.IsZero() {
+		opts.At = time.Now()
+	}
+	warnDays := DefaultWarnDays
+	if opts.WarnDays != nil {
+		warnDays = *opts.WarnDays
+	}
+	info := make(map[string]ProductInfo, len(opts.Info))
+	for _, i := range opts.Info {
+		info[i.Name] = i
+	}
+	names := make([]string, 0, len(products))
+	for name := range products {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tx, err := db.Begin()
+	if err != nil {
+		return export, err
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, err = tx.Exec(sqliteSchema); err != nil {
+		return export, fmt.Errorf("creating schema: %w", err)
+	}
+
+	startedAt := time.Now().UTC().Format(time.RFC3339)
+	for _, name := range names {
+		updated, err := exportSQLiteProduct(tx, name, products[name], info[name], startedAt)
+		if err != nil {
+			return export, fmt.Errorf("exporting %s: %w", name, err)
+		}
+		export.Products++
+		if updated {
+			export.Updated++
+		}
+	}
+	if err = updateSQLiteStatus(tx, opts.At, warnDays); err != nil {
+		return export, fmt.Errorf("updating status: %w", err)
+	}
+
+	_, err = tx.Exec(`INSERT INTO exports (started_at, as_of, source, version, products, updated, failed) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		startedAt, opts.At.Format("2006-01-02"), opts.Source, CurrentVersion, export.Products, export.Updated, len(opts.Failed))
+	if err != nil {
+		return export, err
+	}
+	return export, tx.Commit()
+}
+
+// exportSQLiteProduct upserts a product and rewrites its cycles when their hash changed, reporting whether it did
+func exportSQLiteProduct(tx *sql.Tx, name string, cycles Products, info ProductInfo, fetchedAt string) (bool, error) {
+	data, err := json.Marshal(cycles)
+	if err != nil {
+		return false, err
+	}
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	var previous string
+	err = tx.QueryRow(`SELECT hash FROM products WHERE name = ?`, name).Scan(&previous)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return false, err
+	}
+	_, err = tx.Exec(`INSERT INTO products (name, label, category, cycles, hash, fetched_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(name) DO UPDATE SET
+			label = COALESCE(excluded.label, label),
+			category = COALESCE(excluded.category, category),
+			cycles = excluded.cycles,
+			hash = excluded.hash,
+			fetched_at = excluded.fetched_at,
+			updated_at = CASE WHEN hash = excluded.hash THEN updated_at ELSE excluded.updated_at END`,
+		name, nullString(info.Label), nullString(info.Category), len(cycles), hash, fetchedAt, fetchedAt)
+	if err != nil {
+		return false, err
+	}
+	if previous == hash {
+		return false, nil
+	}
+
+	if _, err = tx.Exec(`DELETE FROM cycle_fields WHERE product = ?`, name); err != nil {
+		return false, err
+	}
+	if _, err = tx.Exec(`DELETE FROM cycles WHERE product = ?`, name); err != nil {
+		return false, err
+	}
+	columns := ProductCSVColumns(cycles)
+	for i, cycle := range cycles {
+		lts, ltsDate := sqliteDateValue(cycle.LTS)
+		support, supportDate := sqliteDateValue(cycle.Support)
+		eol, eolDate := sqliteDateValue(cycle.EOL)
+		extended, extendedDate := sqliteDateValue(cycle.ExtendedSupport)
+		_, err = tx.Exec(`INSERT OR REPLACE INTO cycles (product, cycle, position, release_date, latest, latest_release_date, link,
+			lts, lts_date, support, support_date, eol, eol_date, extended_support, extended_support_date, status)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			name, cycle.Cycle, i, nullString(cycle.ReleaseDate), nullString(cycle.Latest), nullString(cycle.LatestReleaseDate), nullString(cycle.Link),
+			lts, ltsDate, support, supportDate, eol, eolDate, extended, extendedDate, string(StatusUnknown))
+		if err != nil {
+			return false, err
+		}
+		for _, column := range columns {
+			if sqliteCycleColumns[column] {
+				continue
+			}
+			value := cycle.Field(column)
+			if value == nil {
+				continue
+			}
+			text, err := csvValue(value)
+			if err != nil {
+				return false, fmt.Errorf("cycle %s field %s: %w", cycle.Cycle, column, err)
+			}
+			if text == "" {
+				continue
+			}
+			_, err = tx.Exec(`INSERT OR REPLACE INTO cycle_fields (product, cycle, key, value) VALUES (?, ?, ?, ?)`, name, cycle.Cycle, column, text)
+			if err != nil {
+				return false, err
+			}
+		}
+	}
+	return true, nil
+}
+
+// updateSQLiteStatus recomputes the status and days remaining of every cycle in the database at a point in time
+func updateSQLiteStatus(tx *sql.Tx, at time.Time, warnDays int) error {
+	rows, err := tx.Query(`SELECT product, cycle, eol, eol_date, support, support_date FROM cycles`)
+	if err != nil {
+		return err
+	}
+	type update struct {
+		product, cycle string
+		annotation     Annotation
+	}
+	var updates []update
+	for rows.Next() {
+		var product, cycle string
+		var eol, support sql.NullBool
+		var eolDate, supportDate sql.NullString
+		if err = rows.Scan(&product, &cycle, &eol, &eolDate, &support, &supportDate); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		p := Product{Cycle: cycle, EOL: sqliteValue(eol, eolDate), Support: sqliteValue(support, supportDate)}
+		updates = append(updates, update{product: product, cycle: cycle, annotation: p.AnnotationWithin(at, warnDays)})
+	}
+	if err = rows.Close(); err != nil {
+		return err
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare(`UPDATE cycles SET status = ?, days_to_eol = ?, days_to_support_end = ? WHERE product = ? AND cycle = ?`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for _, u := range updates {
+		if _, err = stmt.Exec(string(u.annotation.Status), u.annotation.DaysToEOL, u.annotation.DaysToSupportEnd, u.product, u.cycle); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sqliteDateValue splits a boolean or date field into its boolean, NULL for dates,
+// and its date in YYYY-MM-DD form, NULL for booleans and unparsable values
+func sqliteDateValue(value interface{}) (sql.NullBool, sql.NullString) {
+	switch v := value.(type) {
+	case bool:
+		return sql.NullBool{Bool: v, Valid: true}, sql.NullString{}
+	case string:
+		if date, err := ParseDate(v); err == nil {
+			return sql.NullBool{}, sql.NullString{String: date.Format("2006-01-02"), Valid: true}
+		}
+	}
+	return sql.NullBool{}, sql.NullString{}
+}
+
+// sqliteValue is the inverse of sqliteDateValue, returning the date, the boolean or nil
+func sqliteValue(flag sql.NullBool, date sql.NullString) interface{} {
+	switch {
+	case date.Valid:
+		return date.String
+	case flag.Valid:
+		return flag.Bool
+	default:
+		return nil
+	}
+}
+
+// nullString returns NULL for empty strings
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,113 @@
+package eoldate
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestExportSQLite(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "eol.db"))
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	defer db.Close()
+
+	at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	products := map[string]Products{
+		"ubuntu": {
+			{Cycle: "24.04", ReleaseDate: "2024-04-25", LTS: true, Support: "2029-05-31", EOL: "2029-05-31", ExtendedSupport: "2036-04-25"},
+			{Cycle: "20.04", ReleaseDate: "2020-04-23", LTS: true, Support: "2025-05-29", EOL: "2025-03-01", AdditionalFields: map[string]interface{}{"codename": "Focal Fossa"}},
+		},
+		"python": {
+			{Cycle: "2.7", ReleaseDate: "2010-07-03", EOL: true},
+		},
+	}
+	export, err := ExportSQLite(db, products, SQLiteOptions{At: at, Info: []ProductInfo{{Name: "ubuntu", Label: "Ubuntu", Category: "os"}}})
+	if err != nil {
+		t.Fatalf("ExportSQLite() error = %v", err)
+	}
+	if export.Products != 2 || export.Updated != 2 {
+		t.Errorf("ExportSQLite() = %+v, want 2 products and 2 updated", export)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"eol date", `SELECT eol_date FROM cycles WHERE product = 'ubuntu' AND cycle = '20.04'`, "2025-03-01"},
+		{"approaching eol", `SELECT status FROM cycles WHERE product = 'ubuntu' AND cycle = '20.04'`, string(StatusApproachingEOL)},
+		{"supported", `SELECT status FROM cycles WHERE product = 'ubuntu' AND cycle = '24.04'`, string(StatusSupported)},
+		{"eol boolean", `SELECT status || ' ' || eol || ' ' || IFNULL(eol_date, 'null') FROM cycles WHERE product = 'python'`, "eol 1 null"},
+		{"days to eol", `SELECT days_to_eol FROM cycles WHERE product = 'ubuntu' AND cycle = '20.04'`, "59"},
+		{"additional field", `SELECT value FROM cycle_fields WHERE product = 'ubuntu' AND cycle = '20.04' AND key = 'codename'`, "Focal Fossa"},
+		{"product info", `SELECT label || ' ' || category || ' ' || cycles FROM products WHERE name = 'ubuntu'`, "Ubuntu os 2"},
+		{"eol in range", `SELECT group_concat(product || ' ' || cycle) FROM cycles WHERE eol_date BETWEEN '2025-01-01' AND '2025-03-31'`, "ubuntu 20.04"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			if err := db.QueryRow(tt.query).Scan(&got); err != nil {
+				t.Fatalf("query error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// a second export only rewrites changed products, keeps products that were not passed and refreshes statuses
+	again := map[string]Products{
+		"ubuntu": {
+			{Cycle: "24.04", ReleaseDate: "2024-04-25", LTS: true, Support: "2029-05-31", EOL: "2029-05-31", ExtendedSupport: "2036-04-25"},
+			{Cycle: "20.04", ReleaseDate: "2020-04-23", LTS: true, Support: "2025-05-29", EOL: "2025-03-01", AdditionalFields: map[string]interface{}{"codename": "Focal Fossa"}},
+		},
+	}
+	export, err = ExportSQLite(db, again, SQLiteOptions{At: at.AddDate(0, 6, 0), Failed: []string{"nodejs"}})
+	if err != nil {
+		t.Fatalf("ExportSQLite() second export error = %v", err)
+	}
+	if export.Products != 1 || export.Updated != 0 {
+		t.Errorf("ExportSQLite() second export = %+v, want 1 product and 0 updated", export)
+	}
+	var status, label string
+	var cycles, exports, failed int
+	if err = db.QueryRow(`SELECT status FROM cycles WHERE product = 'ubuntu' AND cycle = '20.04'`).Scan(&status); err != nil {
+		t.Fatal(err)
+	}
+	if status != string(StatusEOL) {
+		t.Errorf("status after second export = %q, want %q", status, StatusEOL)
+	}
+	if err = db.QueryRow(`SELECT label FROM products WHERE name = 'ubuntu'`).Scan(&label); err != nil || label != "Ubuntu" {
+		t.Errorf("label after second export = %q, %v, want it kept", label, err)
+	}
+	if err = db.QueryRow(`SELECT COUNT(*) FROM cycles`).Scan(&cycles); err != nil || cycles != 3 {
+		t.Errorf("cycles after second export = %d, %v, want 3", cycles, err)
+	}
+	if err = db.QueryRow(`SELECT COUNT(*), SUM(failed) FROM exports`).Scan(&exports, &failed); err != nil || exports != 2 || failed != 1 {
+		t.Errorf("exports = %d with %d failed, %v, want 2 with 1 failed", exports, failed, err)
+	}
+
+	// a zero warning window is kept instead of falling back to the default
+	for _, tt := range []struct {
+		warnDays *int
+		want     Status
+	}{
+		{nil, StatusApproachingEOL},
+		{new(int), StatusSupported},
+	} {
+		if _, err = ExportSQLite(db, nil, SQLiteOptions{At: time.Date(2029, 4, 1, 0, 0, 0, 0, time.UTC), WarnDays: tt.warnDays}); err != nil {
+			t.Fatalf("ExportSQLite() error = %v", err)
+		}
+		if err = db.QueryRow(`SELECT status FROM cycles WHERE product = 'ubuntu' AND cycle = '24.04'`).Scan(&status); err != nil {
+			t.Fatal(err)
+		}
+		if status != string(tt.want) {
+			t.Errorf("status with warn days %v = %q, want %q", tt.warnDays, status, tt.want)
+		}
+	}
+}
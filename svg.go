@@ -0,0 +1,160 @@
+package eoldate
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Default dimensions of RenderLifecycleSVG charts
+const (
+	DefaultSVGWidth     = 960
+	DefaultSVGRowHeight = 24
+)
+
+// colors of the lifecycle phases in SVG charts
+const (
+	svgActiveColor   = "#2da44e"
+	svgSecurityColor = "#d4a72c"
+	svgExtendedColor = "#54aeff"
+	svgTodayColor    = "#cf222e"
+	svgTextColor     = "#1f2328"
+	svgGridColor     = "#d0d7de"
+)
+
+// svgPhaseColors are the bar colors of the lifecycle phases
+var svgPhaseColors = map[Phase]string{
+	PhaseActive:   svgActiveColor,
+	PhaseSecurity: svgSecurityColor,
+	PhaseExtended: svgExtendedColor,
+}
+
+// SVGOptions controls the chart drawn by RenderLifecycleSVG
+type SVGOptions struct {
+	// Title is drawn above the chart, usually the product name
+	Title string
+	// Width of the chart in pixels, defaults to DefaultSVGWidth
+	Width int
+	// RowHeight is the height of each cycle in pixels, defaults to DefaultSVGRowHeight
+	RowHeight int
+	// At is the date marked as today, defaults to now
+	At time.Time
+}
+
+// RenderLifecycleSVG writes a Gantt style SVG chart with one bar per cycle,
+// coloring the active support, security support and extended support phases and marking today.
+// Cycles without a release date are left out
+func RenderLifecycleSVG(products Products, w io.Writer, opts SVGOptions) error {
+	if opts.Width <= 0 {
+		opts.Width = DefaultSVGWidth
+	}
+	if opts.RowHeight <= 0 {
+		opts.RowHeight = DefaultSVGRowHeight
+	}
+	if opts.At.IsZero() {
+		opts.At = time.Now()
+	}
+
+	var cycles []Lifecycle
+	start, end := opts.At, opts.At
+	labelChars := 5
+	for _, product := range products {
+		lc, ok := product.Lifecycle()
+		if !ok {
+			continue
+		}
+		for _, t := range lc.Dates() {
+			if t.Before(start) {
+				start = t
+			}
+			if t.After(end) {
+				end = t
+			}
+		}
+		labelChars = max(labelChars, len([]rune(product.Cycle)))
+		cycles = append(cycles, lc)
+	}
+	// start at the beginning of the first year and leave room after the last date for open ended cycles
+	start = time.Date(start.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	end = end.AddDate(0, 6, 0)
+
+	const (
+		margin   = 16
+		titleH   = 32
+		axisH    = 20
+		legendH  = 28
+		charW    = 8
+		barInset = 4
+	)
+	labelW := labelChars*charW + margin
+	chartX := margin + labelW
+	chartW := opts.Width - chartX - margin
+	if chartW < 100 {
+		chartW = 100
+		opts.Width = chartX + chartW + margin
+	}
+	chartY := margin + titleH + axisH
+	height := chartY + len(cycles)*opts.RowHeight + legendH + margin
+	x := func(t time.Time) float64 {
+		return float64(chartX) + float64(t.Sub(start))/float64(end.Sub(start))*float64(chartW)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="Helvetica, Arial, sans-serif" font-size="12">`+"\n",
+		opts.Width, height, opts.Width, height)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", opts.Width, height)
+	if opts.Title != "" {
+		fmt.Fprintf(&buf, `<text x="%d" y="%d" font-size="18" font-weight="bold" fill="%s">%s</text>`+"\n", margin, margin+18, svgTextColor, svgEscape(opts.Title))
+	}
+
+	// year grid and axis labels
+	for year := start.Year(); year <= end.Year(); year++ {
+		yearX := x(time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC))
+		if yearX > float64(chartX+chartW) {
+			break
+		}
+		fmt.Fprintf(&buf, `<line x1="%.1f" y1="%d" x2="%.1f" y2="%d" stroke="%s" stroke-width="1"/>`+"\n",
+			yearX, chartY-4, yearX, chartY+len(cycles)*opts.RowHeight, svgGridColor)
+		fmt.Fprintf(&buf, `<text x="%.1f" y="%d" fill="%s">%d</text>`+"\n", yearX+2, chartY-8, svgTextColor, year)
+	}
+
+	for i, lc := range cycles {
+		rowY := chartY + i*opts.RowHeight
+		fmt.Fprintf(&buf, `<text x="%d" y="%d" fill="%s">%s</text>`+"\n", margin, rowY+opts.RowHeight/2+4, svgTextColor, svgEscape(lc.Cycle))
+		for _, phase := range lc.Phases(end) {
+			fmt.Fprintf(&buf, `<rect x="%.1f" y="%d" width="%.1f" height="%d" fill="%s"><title>%s %s: %s to %s</title></rect>`+"\n",
+				x(phase.Start), rowY+barInset, x(phase.End)-x(phase.Start), opts.RowHeight-2*barInset, svgPhaseColors[phase.Phase],
+				svgEscape(lc.Cycle), phase.Phase, phase.Start.Format("2006-01-02"), phase.End.Format("2006-01-02"))
+		}
+	}
+
+	todayX := x(opts.At)
+	fmt.Fprintf(&buf, `<line x1="%.1f" y1="%d" x2="%.1f" y2="%d" stroke="%s" stroke-width="2"/>`+"\n",
+		todayX, chartY-4, todayX, chartY+len(cycles)*opts.RowHeight, svgTodayColor)
+
+	legendY := chartY + len(cycles)*opts.RowHeight + legendH - 8
+	legendX := chartX
+	for _, item := range []struct{ color, label string }{
+		{svgActiveColor, string(PhaseActive)},
+		{svgSecurityColor, string(PhaseSecurity)},
+		{svgExtendedColor, string(PhaseExtended)},
+		{svgTodayColor, "today " + opts.At.Format("2006-01-02")},
+	} {
+		fmt.Fprintf(&buf, `<rect x="%d" y="%d" width="12" height="12" fill="%s"/>`+"\n", legendX, legendY-10, item.color)
+		fmt.Fprintf(&buf, `<text x="%d" y="%d" fill="%s">%s</text>`+"\n", legendX+16, legendY, svgTextColor, item.label)
+		legendX += 16 + len(item.label)*7 + 24
+	}
+	buf.WriteString("</svg>\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// svgEscape escapes text for use in SVG elements
+func svgEscape(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
@@ -0,0 +1,50 @@
+package eoldate
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderLifecycleSVG(t *testing.T) {
+	products := Products{
+		{Cycle: "22.04", ReleaseDate: "2022-04-21", Support: "2024-09-30", EOL: "2027-06-01", ExtendedSupport: "2032-04-09"},
+		{Cycle: "<beta>", ReleaseDate: "2023-10-12", EOL: true},
+		{Cycle: "24.04", ReleaseDate: "2024-04-25", EOL: false},
+		{Cycle: "no release date", EOL: "2020-01-01"},
+	}
+	var buf bytes.Buffer
+	err := RenderLifecycleSVG(products, &buf, SVGOptions{Title: "ubuntu & friends", At: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatalf("RenderLifecycleSVG() error = %v", err)
+	}
+
+	dec := xml.NewDecoder(bytes.NewReader(buf.Bytes()))
+	for {
+		if _, err = dec.Token(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("RenderLifecycleSVG() wrote invalid XML: %v\n%s", err, buf.String())
+		}
+	}
+
+	svg := buf.String()
+	for _, want := range []string{
+		"22.04 active support: 2022-04-21 to 2024-09-30",
+		"22.04 security support: 2024-09-30 to 2027-06-01",
+		"22.04 extended support: 2027-06-01 to 2032-04-09",
+		"&lt;beta&gt;",
+		"ubuntu &amp; friends",
+		"today 2025-01-01",
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("RenderLifecycleSVG() output does not contain %q", want)
+		}
+	}
+	if strings.Contains(svg, "no release date") {
+		t.Errorf("RenderLifecycleSVG() drew a cycle without a release date")
+	}
+}
@@ -102,14 +102,22 @@ func WriteStructToJSONFile(data interface{}, outputFile string) error {
 	return nil
 }
 
-// WriteStructToCSVFile ...
+// WriteStructToCSVFile writes a slice of structs to a CSV file, replacing any previous content.
+// Cycles are written with WriteProductsCSV so their AdditionalFields are kept
 func WriteStructToCSVFile(data interface{}, outputFile string) error {
+	switch products := data.(type) {
+	case Products:
+		return WriteProductsCSVFile(products, outputFile)
+	case []Product:
+		return WriteProductsCSVFile(products, outputFile)
+	}
+
 	outputFileDir := filepath.Dir(outputFile)
 	if err := os.MkdirAll(outputFileDir, 0750); err != nil {
 		return LogError(err)
 	}
 
-	file, err := os.OpenFile(outputFile, os.O_RDWR|os.O_CREATE, 0644)
+	file, err := os.OpenFile(outputFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
 	if err != nil {
 		return LogError(err)
 	}
@@ -161,7 +169,11 @@ func ResolveAbsPath(path string) (string, error) {
 
 // CalculateTimeDifference calculates the difference between the current date and the given endDate
 func CalculateTimeDifference(endDate time.Time) (int, int, int) {
-	now := time.Now()
+	return CalculateTimeDifferenceAt(endDate, time.Now())
+}
+
+// CalculateTimeDifferenceAt calculates the difference between now and the given endDate
+func CalculateTimeDifferenceAt(endDate, now time.Time) (int, int, int) {
 	if endDate.After(now) {
 		// For future dates
 		return diffDates(now, endDate)
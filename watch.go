@@ -0,0 +1,190 @@
+package eoldate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// lastPollFileName is the file in the history directory of a product holding its watchState.
+// It has no .json extension so it is not listed as a snapshot
+const lastPollFileName = "last-poll"
+
+// watchState is what a Watcher saw of a product at its last poll
+type watchState struct {
+	Time     time.Time `json:"time"`
+	Products Products  `json:"products"`
+}
+
+// WatchOptions controls how a Watcher reports changes
+type WatchOptions struct {
+	// WarnDays reports cycles reaching EOL within this many days as approaching EOL, nil defaults to DefaultWarnDays
+	WarnDays *int
+}
+
+// Watcher refreshes a set of products and reports the changes since the previous poll
+type Watcher struct {
+	client   *Client
+	products []string
+	// warnDays is the warning window of WatchOptions.WarnDays
+	warnDays int
+}
+
+// NewWatcher creates a Watcher of the given products
+func (c *Client) NewWatcher(products []string, opts WatchOptions) *Watcher {
+	warnDays := DefaultWarnDays
+	if opts.WarnDays != nil {
+		warnDays = *opts.WarnDays
+	}
+	return &Watcher{client: c, products: products, warnDays: warnDays}
+}
+
+// Poll refreshes every product from the API and returns the events since the previous poll,
+// and the errors of products that could not be refreshed keyed by name.
+//
+// Added and removed cycles, new latest versions and changed dates are found by comparing the refreshed data
+// with the data of the previous poll, or with the latest snapshot on the first poll of a product.
+// Cycles reaching EOL or entering the warning window are reported by comparing their status with the status
+// at the previous poll. Each poll is recorded next to the snapshots, so a new Watcher of the same cache,
+// such as a command run from cron, continues where the previous one stopped
+// and changes fetched by other commands in between are still reported
+func (w *Watcher) Poll(at time.Time) ([]Event, map[string]error) {
+	var events []Event
+	errs := make(map[string]error)
+	for _, name := range w.products {
+		productEvents, err := w.pollProduct(name, at)
+		events = append(events, productEvents...)
+		if err != nil {
+			errs[name] = err
+		}
+	}
+	return events, errs
+}
+
+// pollProduct refreshes a product and returns its events
+func (w *Watcher) pollProduct(name string, at time.Time) ([]Event, error) {
+	state, err := w.client.lastPoll(name)
+	if err != nil {
+		return nil, err
+	}
+	var previous Products
+	var lastPoll time.Time
+	if state != nil {
+		previous, lastPoll = state.Products, state.Time
+	} else {
+		var snapshots []Snapshot
+		if snapshots, err = w.client.Snapshots(name); err != nil {
+			return nil, err
+		}
+		if len(snapshots) > 0 {
+			if previous, err = snapshots[len(snapshots)-1].Products(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	current, err := w.client.RefreshProduct(name)
+	if err != nil {
+		return nil, err
+	}
+
+	cycles := make(map[string]Product, len(current)+len(previous))
+	for _, product := range previous {
+		cycles[product.Cycle] = product
+	}
+	for _, product := range current {
+		cycles[product.Cycle] = product
+	}
+
+	var events []Event
+	if previous != nil {
+		for _, change := range DiffProducts(previous, current) {
+			product := cycles[change.Cycle]
+			event := newEvent(name, product, at, w.warnDays)
+			event.Field, event.Old, event.New = change.Field, change.Old, change.New
+			switch {
+			case change.Kind == ChangeAdded:
+				event.Kind = EventCycleAdded
+				event.Message = fmt.Sprintf("%s %s was added", name, change.Cycle)
+			case change.Kind == ChangeRemoved:
+				event.Kind = EventCycleRemoved
+				event.Message = fmt.Sprintf("%s %s was removed", name, change.Cycle)
+			case change.Field == "latest":
+				event.Kind = EventNewRelease
+				event.Message = fmt.Sprintf("%s %s released %s", name, change.Cycle, changeValue(change.New))
+			default:
+				event.Kind = EventDateChanged
+				event.Message = fmt.Sprintf("%s %s", name, change)
+			}
+			events = append(events, event)
+		}
+	}
+
+	if lastPoll.IsZero() {
+		return events, w.client.writeLastPoll(name, watchState{Time: at, Products: current})
+	}
+	for i := range current {
+		before := current[i].AnnotationWithin(lastPoll, w.warnDays).Status
+		event := newEvent(name, current[i], at, w.warnDays)
+		if event.Status == before {
+			continue
+		}
+		switch event.Status {
+		case StatusEOL:
+			event.Kind = EventEOL
+			event.Message = fmt.Sprintf("%s %s reached its end of life", name, current[i].Cycle)
+		case StatusApproachingEOL:
+			event.Kind = EventApproachingEOL
+			event.Message = fmt.Sprintf("%s %s reaches its end of life in %d days on %s", name, current[i].Cycle, *event.DaysToEOL, event.EOLDate)
+		default:
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, w.client.writeLastPoll(name, watchState{Time: at, Products: current})
+}
+
+// lastPoll returns what a Watcher saw of a product at its last poll, or nil when it was never polled
+func (c *Client) lastPoll(product string) (*watchState, error) {
+	historyDir, err := c.HistoryDir()
+	if err != nil {
+		return nil, err
+	}
+	file := filepath.Join(historyDir, product, lastPollFileName)
+	data, err := os.ReadFile(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, &CacheError{Op: "read", Path: file, Err: err}
+	}
+	var state watchState
+	if err = json.Unmarshal(data, &state); err != nil {
+		return nil, &CacheError{Op: "decode", Path: file, Err: err}
+	}
+	return &state, nil
+}
+
+// writeLastPoll records what a Watcher saw of a product
+func (c *Client) writeLastPoll(product string, state watchState) error {
+	historyDir, err := c.HistoryDir()
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(historyDir, product)
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return &CacheError{Op: "mkdir", Path: dir, Err: err}
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	file := filepath.Join(dir, lastPollFileName)
+	if err = os.WriteFile(file, data, 0600); err != nil {
+		return &CacheError{Op: "write", Path: file, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package eoldate
+
+import (
+	"net/http"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWatcher_Poll(t *testing.T) {
+	var data atomic.Value
+	data.Store(`[{"cycle":"8.3","eol":"2027-12-31","latest":"8.3.12"},{"cycle":"8.2","eol":"2026-12-31","latest":"8.2.24"}]`)
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/all.json":
+			_, _ = w.Write([]byte(`["php"]`))
+		case "/php.json":
+			_, _ = w.Write([]byte(data.Load().(string)))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	warnDays := 90
+	watcher := c.NewWatcher([]string{"php", "unknown"}, WatchOptions{WarnDays: &warnDays})
+
+	at := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	events, errs := watcher.Poll(at)
+	if len(events) != 0 {
+		t.Errorf("first Poll() without snapshots = %+v, want no events", events)
+	}
+	if len(errs) != 1 || errs["unknown"] == nil {
+		t.Errorf("first Poll() errs = %v, want an error for unknown", errs)
+	}
+
+	data.Store(`[{"cycle":"8.4","eol":"2028-12-31","latest":"8.4.0"},{"cycle":"8.3","eol":"2028-01-31","latest":"8.3.13"},{"cycle":"8.2","eol":"2026-12-31","latest":"8.2.24"}]`)
+	events, _ = watcher.Poll(at.AddDate(0, 1, 5))
+	got := make(map[EventKind]string)
+	for _, event := range events {
+		got[event.Kind] = event.Cycle
+	}
+	want := map[EventKind]string{
+		EventCycleAdded:     "8.4",
+		EventNewRelease:     "8.3",
+		EventDateChanged:    "8.3",
+		EventApproachingEOL: "8.2",
+	}
+	if len(events) != len(want) {
+		t.Errorf("second Poll() = %+v, want %d events", events, len(want))
+	}
+	for kind, cycle := range want {
+		if got[kind] != cycle {
+			t.Errorf("second Poll() %s event for cycle %q, want %q", kind, got[kind], cycle)
+		}
+	}
+	for _, event := range events {
+		if event.Kind == EventApproachingEOL && (event.EOLDate != "2026-12-31" || event.DaysToEOL == nil || *event.DaysToEOL != 86) {
+			t.Errorf("approaching EOL event = %+v, want EOL date 2026-12-31 in 86 days", event)
+		}
+	}
+
+	// nothing changed upstream and no cycle crossed a threshold
+	if events, _ = watcher.Poll(at.AddDate(0, 1, 6)); len(events) != 0 {
+		t.Errorf("third Poll() = %+v, want no events", events)
+	}
+	if events, _ = watcher.Poll(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)); len(events) != 1 || events[0].Kind != EventEOL || events[0].Cycle != "8.2" {
+		t.Errorf("Poll() after 8.2 EOL = %+v, want an eol event for 8.2", events)
+	}
+}
+
+// newPHPWatchClient returns a client of a fake API serving php 8.3 and 8.2, which reaches EOL on 2026-12-31
+func newPHPWatchClient(t *testing.T) *Client {
+	t.Helper()
+	return newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/all.json":
+			_, _ = w.Write([]byte(`["php"]`))
+		case "/php.json":
+			_, _ = w.Write([]byte(`[{"cycle":"8.3","eol":"2027-12-31","latest":"8.3.12"},{"cycle":"8.2","eol":"2026-12-31","latest":"8.2.24"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestWatcher_PollOnce(t *testing.T) {
+	c := newPHPWatchClient(t)
+	// like watch -once from cron, every poll is made by a new Watcher of the same cache
+	tests := []struct {
+		name string
+		at   time.Time
+		want []EventKind
+	}{
+		{name: "first poll", at: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "entering the warning window", at: time.Date(2026, 10, 6, 0, 0, 0, 0, time.UTC), want: []EventKind{EventApproachingEOL}},
+		{name: "no crossing", at: time.Date(2026, 10, 7, 0, 0, 0, 0, time.UTC)},
+		{name: "reaching eol", at: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), want: []EventKind{EventEOL}},
+	}
+	for _, tt := range tests {
+		warnDays := 90
+		events, errs := c.NewWatcher([]string{"php"}, WatchOptions{WarnDays: &warnDays}).Poll(tt.at)
+		if len(errs) != 0 {
+			t.Fatalf("%s: Poll() errs = %v", tt.name, errs)
+		}
+		var got []EventKind
+		for _, event := range events {
+			got = append(got, event.Kind)
+			if event.Cycle != "8.2" {
+				t.Errorf("%s: Poll() event for cycle %q, want 8.2", tt.name, event.Cycle)
+			}
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Poll() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if state, err := c.lastPoll("php"); err != nil || state == nil || !state.Time.Equal(tests[len(tests)-1].at) || len(state.Products) != 2 {
+		t.Errorf("lastPoll() = %+v, %v, want the time and data of the last poll", state, err)
+	}
+}
+
+func TestWatcher_PollZeroWarnDays(t *testing.T) {
+	c := newPHPWatchClient(t)
+	warnDays := 0
+	watcher := c.NewWatcher([]string{"php"}, WatchOptions{WarnDays: &warnDays})
+	for _, at := range []time.Time{
+		time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 12, 30, 0, 0, 0, 0, time.UTC),
+	} {
+		if events, errs := watcher.Poll(at); len(events) != 0 || len(errs) != 0 {
+			t.Errorf("Poll(%s) without a warning window = %+v, %v, want no events", at.Format("2006-01-02"), events, errs)
+		}
+	}
+	if events, _ := watcher.Poll(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)); len(events) != 1 || events[0].Kind != EventEOL {
+		t.Errorf("Poll() after 8.2 EOL = %+v, want an eol event", events)
+	}
+}
+
+func TestWatcher_PollAfterOtherFetch(t *testing.T) {
+	var data atomic.Value
+	data.Store(`[{"cycle":"8.3","eol":"2027-12-31","latest":"8.3.12"}]`)
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/all.json":
+			_, _ = w.Write([]byte(`["php"]`))
+		case "/php.json":
+			_, _ = w.Write([]byte(data.Load().(string)))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	at := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	if _, errs := c.NewWatcher([]string{"php"}, WatchOptions{}).Poll(at); len(errs) != 0 {
+		t.Fatalf("first Poll() errs = %v", errs)
+	}
+
+	// another command fetches the new release first and records it as the latest snapshot
+	data.Store(`[{"cycle":"8.3","eol":"2027-12-31","latest":"8.3.13"}]`)
+	if _, err := c.RefreshProduct("php"); err != nil {
+		t.Fatal(err)
+	}
+	events, errs := c.NewWatcher([]string{"php"}, WatchOptions{}).Poll(at.AddDate(0, 0, 1))
+	if len(errs) != 0 || len(events) != 1 || events[0].Kind != EventNewRelease || events[0].New != "8.3.13" {
+		t.Errorf("Poll() after another fetch = %+v, %v, want the new release", events, errs)
+	}
+}